@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// bigint makes the solvers whose answers may not fit in an int compute them with math/big, set with --bigint
+var bigint bool
+
+// Answer is the solution to one part of a day's puzzle, exact however large it is
+type Answer struct {
+	// value is nil for a zero answer
+	value *big.Int
+	// wrapped is set when the answer was computed natively and overflowed int, so it's wrong
+	wrapped bool
+}
+
+// IntAnswer returns the answer n
+func IntAnswer(n int) Answer {
+	return Answer{value: big.NewInt(int64(n))}
+}
+
+// BigAnswer returns the answer n, which may not fit in an int
+func BigAnswer(n *big.Int) Answer {
+	return Answer{value: new(big.Int).Set(n)}
+}
+
+func (a Answer) String() string {
+	if a.value == nil {
+		return "0"
+	}
+	return a.value.String()
+}
+
+// MarshalJSON writes the answer as a JSON number, however large it is
+func (a Answer) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// Equal tells whether both answers are the same number
+func (a Answer) Equal(b Answer) bool {
+	return a.String() == b.String()
+}
+
+// Wrapped tells whether the answer was computed natively and overflowed, in which case --bigint gets it right
+func (a Answer) Wrapped() bool {
+	return a.wrapped
+}
+
+var (
+	minInt = big.NewInt(math.MinInt)
+	maxInt = big.NewInt(math.MaxInt)
+)
+
+// Overflows tells whether the answer doesn't fit in an int
+func (a Answer) Overflows() bool {
+	return a.value != nil && (a.value.Cmp(minInt) < 0 || a.value.Cmp(maxInt) > 0)
+}
+
+// Native returns the answer as a native computation would have got it, wrapping around the size of int
+func (a Answer) Native() int {
+	if a.value == nil {
+		return 0
+	}
+	modulus := new(big.Int).Lsh(big.NewInt(1), strconv.IntSize)
+	n := new(big.Int).Mod(a.value, modulus)
+	if n.Cmp(maxInt) > 0 {
+		n.Sub(n, modulus)
+	}
+	return int(n.Int64())
+}
+
+// counter is an integer computed natively, remembering whether it overflowed, or exactly in --bigint mode
+type counter struct {
+	n       int
+	big     *big.Int // only set in --bigint mode
+	wrapped bool
+}
+
+func newCounter(n int) counter {
+	if bigint {
+		return counter{big: big.NewInt(int64(n))}
+	}
+	return counter{n: n}
+}
+
+func (c counter) exact() *big.Int {
+	if c.big != nil {
+		return c.big
+	}
+	return big.NewInt(int64(c.n))
+}
+
+func (c counter) add(other counter) counter {
+	if c.big != nil || other.big != nil {
+		return counter{big: new(big.Int).Add(c.exact(), other.exact())}
+	}
+	sum := c.n + other.n
+	overflow := (c.n > 0 && other.n > 0 && sum < 0) || (c.n < 0 && other.n < 0 && sum >= 0)
+	return counter{n: sum, wrapped: c.wrapped || other.wrapped || overflow}
+}
+
+func (c counter) mul(other counter) counter {
+	if c.big != nil || other.big != nil {
+		return counter{big: new(big.Int).Mul(c.exact(), other.exact())}
+	}
+	product := c.n * other.n
+	overflow := c.n != 0 && (product/c.n != other.n || (c.n == -1 && other.n == math.MinInt))
+	return counter{n: product, wrapped: c.wrapped || other.wrapped || overflow}
+}
+
+// float approximates the counter, for scales and ratios
+func (c counter) float() float64 {
+	f, _ := new(big.Float).SetInt(c.exact()).Float64()
+	return f
+}
+
+func (c counter) answer() Answer {
+	if c.big != nil {
+		return BigAnswer(c.big)
+	}
+	a := IntAnswer(c.n)
+	a.wrapped = c.wrapped
+	return a
+}
+
+func (c counter) String() string {
+	return c.answer().String()
+}
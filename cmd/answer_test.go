@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestCounterOverflow(t *testing.T) {
+	defer func(mode bool) { bigint = mode }(bigint)
+
+	bigint = false
+	if c := newCounter(math.MaxInt / 2).add(newCounter(math.MaxInt / 2)); c.answer().Wrapped() {
+		t.Errorf("%v fits in an int, it didn't overflow", c)
+	}
+	if c := newCounter(math.MaxInt).add(newCounter(1)); !c.answer().Wrapped() {
+		t.Error("MaxInt + 1 overflowed, it must be reported")
+	}
+	if c := newCounter(1 << 40).mul(newCounter(1 << 40)).add(newCounter(1)); !c.answer().Wrapped() {
+		t.Error("2^80 overflowed, it must be reported along the following operations")
+	}
+
+	bigint = true
+	a := newCounter(1 << 40).mul(newCounter(1 << 40)).add(newCounter(1)).answer()
+	if a.Wrapped() || !a.Overflows() || a.String() != "1208925819614629174706177" {
+		t.Errorf("got %v, want 2^80 + 1 computed exactly", a)
+	}
+	if a.Native() != 1 {
+		t.Errorf("2^80 + 1 wraps to 1 natively, got %d", a.Native())
+	}
+}
+
+func TestAnswer(t *testing.T) {
+	huge, _ := new(big.Int).SetString("99999999999999999999", 10)
+	out, err := json.Marshal(Result{Answer: BigAnswer(huge)})
+	if err != nil || !strings.Contains(string(out), `"answer":99999999999999999999`) {
+		t.Errorf("got (%s, %v), want the answer as an exact JSON number", out, err)
+	}
+	if !(Answer{}).Equal(IntAnswer(0)) || IntAnswer(-3).Native() != -3 {
+		t.Error("the zero answer must be 0")
+	}
+}
+
+func TestWrappedRecords(t *testing.T) {
+	defer func(mode bool) { bigint = mode }(bigint)
+	bigint = false
+
+	r := Result{Day: 7, Part: 2, Answer: newCounter(math.MaxInt).add(newCounter(1)).answer(), Input: "in"}
+	for format, want := range map[string]string{
+		"json": `"wrapped":true`,
+		"tsv":  "\tin\t0\t\ttrue\n",
+	} {
+		var out strings.Builder
+		w, _ := newResultWriter(format, &out)
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("%s: got %q, want the overflow flagged with %q", format, out.String(), want)
+		}
+	}
+}
+
+// doublingManifold has 70 rows of splitters, each of them doubling the paths: they add up to 2^70
+func doublingManifold() string {
+	rows := []string{strings.Repeat(".", 70) + "S" + strings.Repeat(".", 70)}
+	for r := 1; r <= 70; r++ {
+		row := []byte(strings.Repeat(".", 141))
+		for col := 71 - r; col < 70+r; col += 2 {
+			row[col] = '^'
+		}
+		rows = append(rows, string(row))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func TestBigintPaths(t *testing.T) {
+	defer func(mode bool) { bigint = mode }(bigint)
+
+	d, _ := LookupDay(7)
+	for mode, want := range map[bool]string{false: "0", true: "1180591620717411303424"} {
+		bigint = mode
+		solutions, err := d.Solve(context.Background(), strings.NewReader(doublingManifold()), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := solutions[0].Answer; got.String() != want || got.Wrapped() == mode {
+			t.Errorf("bigint %v: got %v (wrapped: %v), want %s", mode, got, got.Wrapped(), want)
+		}
+	}
+}
+
+func TestBigintFreshIds(t *testing.T) {
+	defer func(mode bool) { bigint = mode }(bigint)
+
+	// two ranges of 2^62 ids each, and a third one up to the largest int
+	input := "0-4611686018427387903\n4611686018427387904-9223372036854775807\n9223372036854775807-9223372036854775807\n\n1\n"
+	d, _ := LookupDay(5)
+	for mode, want := range map[bool]string{false: "-9223372036854775808", true: "9223372036854775808"} {
+		bigint = mode
+		solutions, err := d.Solve(context.Background(), strings.NewReader(input), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := solutions[0].Answer; got.String() != want || got.Wrapped() == mode {
+			t.Errorf("bigint %v: got %v (wrapped: %v), want %s", mode, got, got.Wrapped(), want)
+		}
+	}
+}
+
+func TestBigintTimelines(t *testing.T) {
+	defer func(mode bool) { bigint = mode }(bigint)
+
+	d, _ := LookupDay(7)
+	for mode, want := range map[bool]string{
+		false: "row 70: 70 splits, 71 beams and 0 timelines (overflowed int, explore with --bigint for the exact count) leaving it\n",
+		true:  "row 70: 70 splits, 71 beams and 1180591620717411303424 timelines leaving it\n",
+	} {
+		bigint = mode
+		s := d.New()
+		// with a last row where the beams carry on down, to draw them all
+		if err := s.Parse(strings.NewReader(doublingManifold() + "\n" + strings.Repeat(".", 141))); err != nil {
+			t.Fatal(err)
+		}
+		var out, svg strings.Builder
+		if err := s.(Explorer).Explore(context.Background(), &out, "step", []string{"71"}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(out.String(), want) {
+			t.Errorf("bigint %v: got\n%s\nwant it to end with %q", mode, out.String(), want)
+		}
+		// the busiest beams of the last row carry more than an int holds
+		if err := s.(Renderer).Render(context.Background(), &svg); err != nil {
+			t.Fatal(err)
+		}
+		if flagged := strings.Contains(svg.String(), "overflowed int"); flagged == mode {
+			t.Errorf("bigint %v: the rendering flags overflowed timelines: %v", mode, flagged)
+		}
+		if strings.Contains(svg.String(), "NaN") {
+			t.Errorf("bigint %v: the rendering has beams of no width", mode)
+		}
+	}
+}
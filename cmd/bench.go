@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench <day>",
+	Short: "Time parsing and solving of a day over several runs",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBench,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+	benchCmd.Flags().IntP("runs", "n", 10, "number of runs per part")
+	benchCmd.Flags().String("baseline", "", "compare against the results saved in this JSON file")
+	benchCmd.Flags().String("save", "", "save the results as a JSON baseline to this file")
+	benchCmd.Flags().Float64("tolerance", 0.1, "relative slowdown of a median over the baseline reported as a regression")
+}
+
+// timingStats summarizes the durations of several runs
+type timingStats struct {
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	P95    time.Duration `json:"p95_ns"`
+}
+
+// benchReport holds the measurements of one part of a day
+type benchReport struct {
+	Day   int         `json:"day"`
+	Part  int         `json:"part"`
+	Runs  int         `json:"runs"`
+	Parse timingStats `json:"parse"`
+	Solve timingStats `json:"solve"`
+	// Allocs and Bytes are the mean heap allocations per run, parse and solve included
+	Allocs uint64 `json:"allocs"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	d, err := dayFromArg(args[0])
+	if err != nil {
+		return err
+	}
+	runs, _ := cmd.Flags().GetInt("runs")
+	if runs < 1 {
+		return fmt.Errorf("--runs must be at least 1, got %d", runs)
+	}
+
+	inputFiles, err := selectInputFiles(cmd, d.Number)
+	if err != nil {
+		return err
+	}
+	if len(inputFiles) != 1 {
+		return errors.New("bench takes a single input file")
+	}
+	input, err := readInput(inputFiles[0])
+	if err != nil {
+		return err
+	}
+
+	reports := []benchReport{}
+	for part := 1; part <= 2; part++ {
+		report, err := benchPart(cmd.Context(), d, part, input, runs)
+		if err != nil {
+			return fmt.Errorf("part %d: %w", part, err)
+		}
+		reports = append(reports, report)
+	}
+	printBenchReports(cmd.OutOrStdout(), reports)
+
+	if save, _ := cmd.Flags().GetString("save"); save != "" {
+		if err := saveBenchReports(save, reports); err != nil {
+			return err
+		}
+		log.Info().Msgf("Saved baseline to %s", save)
+	}
+
+	if baselineFile, _ := cmd.Flags().GetString("baseline"); baselineFile != "" {
+		baseline, err := loadBenchReports(baselineFile)
+		if err != nil {
+			return err
+		}
+		tolerance, _ := cmd.Flags().GetFloat64("tolerance")
+		if regressions := compareBenchReports(cmd.OutOrStdout(), reports, baseline, tolerance); regressions > 0 {
+			return fmt.Errorf("%d regressions over the baseline", regressions)
+		}
+	}
+	return nil
+}
+
+// readInput loads a whole input file in memory, so that reading it from disk isn't measured
+func readInput(filename string) ([]byte, error) {
+	file, err := openInput(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+func benchPart(ctx context.Context, d Day, part int, input []byte, runs int) (benchReport, error) {
+	parseTimes := make([]time.Duration, runs)
+	solveTimes := make([]time.Duration, runs)
+	var before, after runtime.MemStats
+
+	var err error
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	withPartLabels(ctx, d.Number, part, func(ctx context.Context) {
+		for i := range runs {
+			var s Solver
+			if s, err = d.newSolver(); err != nil {
+				return
+			}
+
+			start := time.Now()
+			if err = s.Parse(bytes.NewReader(input)); err != nil {
+				return
+			}
+			parseTimes[i] = time.Since(start)
+
+			start = time.Now()
+			if part == 2 {
+				_, err = s.Part2(ctx)
+			} else {
+				_, err = s.Part1(ctx)
+			}
+			solveTimes[i] = time.Since(start)
+			if err != nil {
+				return
+			}
+		}
+	})
+	runtime.ReadMemStats(&after)
+	if err != nil {
+		return benchReport{}, err
+	}
+
+	return benchReport{
+		Day:    d.Number,
+		Part:   part,
+		Runs:   runs,
+		Parse:  summarize(parseTimes),
+		Solve:  summarize(solveTimes),
+		Allocs: (after.Mallocs - before.Mallocs) / uint64(runs),
+		Bytes:  (after.TotalAlloc - before.TotalAlloc) / uint64(runs),
+	}, nil
+}
+
+func summarize(durations []time.Duration) timingStats {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	return timingStats{
+		Min:    sorted[0],
+		Median: sorted[len(sorted)/2],
+		P95:    sorted[(len(sorted)*95+99)/100-1],
+	}
+}
+
+func printBenchReports(w io.Writer, reports []benchReport) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tPHASE\tMIN\tMEDIAN\tP95\tALLOCS/RUN\tBYTES/RUN")
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%d\t%d\tparse\t%v\t%v\t%v\t%d\t%d\n", r.Day, r.Part, r.Parse.Min, r.Parse.Median, r.Parse.P95, r.Allocs, r.Bytes)
+		fmt.Fprintf(tw, "%d\t%d\tsolve\t%v\t%v\t%v\t\t\n", r.Day, r.Part, r.Solve.Min, r.Solve.Median, r.Solve.P95)
+	}
+	tw.Flush()
+}
+
+// saveBenchReports stores reports in a baseline file, replacing those of the same day and part already in it
+func saveBenchReports(filename string, reports []benchReport) error {
+	saved, err := loadBenchReports(filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	saved = slices.DeleteFunc(saved, func(old benchReport) bool {
+		return slices.ContainsFunc(reports, func(r benchReport) bool {
+			return r.Day == old.Day && r.Part == old.Part
+		})
+	})
+	saved = append(saved, reports...)
+	slices.SortFunc(saved, func(a, b benchReport) int {
+		if a.Day != b.Day {
+			return a.Day - b.Day
+		}
+		return a.Part - b.Part
+	})
+
+	content, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(content, '\n'), 0o644)
+}
+
+func loadBenchReports(filename string) ([]benchReport, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	reports := []benchReport{}
+	if err := json.Unmarshal(content, &reports); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return reports, nil
+}
+
+// compareBenchReports reports how each median moved from the baseline, and returns how many
+// of them got slower than the tolerance allows
+func compareBenchReports(w io.Writer, reports, baseline []benchReport, tolerance float64) int {
+	regressions := 0
+	compare := func(r benchReport, phase string, current, previous time.Duration) {
+		change := float64(current-previous) / float64(previous)
+		verdict := "ok"
+		if change > tolerance {
+			verdict = "REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(w, "day %d part %d %s: %v -> %v (%+.1f%%) %s\n", r.Day, r.Part, phase, previous, current, change*100, verdict)
+	}
+
+	for _, r := range reports {
+		i := slices.IndexFunc(baseline, func(b benchReport) bool {
+			return b.Day == r.Day && b.Part == r.Part
+		})
+		if i < 0 {
+			fmt.Fprintf(w, "day %d part %d: not in baseline\n", r.Day, r.Part)
+			continue
+		}
+		compare(r, "parse", r.Parse.Median, baseline[i].Parse.Median)
+		compare(r, "solve", r.Solve.Median, baseline[i].Solve.Median)
+	}
+	return regressions
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	durations := make([]time.Duration, 20)
+	for i := range durations {
+		// unsorted, from 20ms down to 1ms
+		durations[i] = time.Duration(20-i) * time.Millisecond
+	}
+	want := timingStats{Min: time.Millisecond, Median: 11 * time.Millisecond, P95: 19 * time.Millisecond}
+	if got := summarize(durations); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got := summarize([]time.Duration{time.Second}); got.Min != time.Second || got.P95 != time.Second {
+		t.Errorf("got %+v, want every statistic of a single run to be its duration", got)
+	}
+}
+
+func TestCompareBenchReports(t *testing.T) {
+	report := func(part int, parse, solve time.Duration) benchReport {
+		return benchReport{Day: 1, Part: part, Parse: timingStats{Median: parse}, Solve: timingStats{Median: solve}}
+	}
+	baseline := []benchReport{report(1, 100, 100)}
+	reports := []benchReport{report(1, 105, 150), report(2, 100, 100)}
+
+	var out strings.Builder
+	if regressions := compareBenchReports(&out, reports, baseline, 0.1); regressions != 1 {
+		t.Errorf("got %d regressions, want the solve phase only\n%s", regressions, out.String())
+	}
+	for _, want := range []string{"day 1 part 1 parse: 100ns -> 105ns (+5.0%) ok", "day 1 part 1 solve: 100ns -> 150ns (+50.0%) REGRESSION", "day 1 part 2: not in baseline"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("got\n%s\nwant it to contain %q", out.String(), want)
+		}
+	}
+}
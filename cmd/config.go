@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// projectConfigFile is looked up in the working directory
+const projectConfigFile = ".adventofcode2025.yaml"
+
+// setting is a value that can be given, from highest to lowest precedence, as a command line flag,
+// an environment variable, a key of the project configuration file or a key of the user configuration file
+type setting struct {
+	key  string
+	flag string // empty if the setting has no flag
+	env  string
+}
+
+var settings = []setting{
+	{key: "inputs_dir", flag: "inputs-dir", env: inputsDirEnv},
+	{key: "log_level", flag: "log-level", env: "AOC_LOG_LEVEL"},
+	{key: "output", flag: "output", env: "AOC_OUTPUT"},
+	{key: "jobs", flag: "jobs", env: "AOC_JOBS"},
+	{key: "workers", flag: "workers", env: "AOC_WORKERS"},
+	{key: "timeout", flag: "timeout", env: "AOC_TIMEOUT"},
+	{key: "bigint", flag: "bigint", env: "AOC_BIGINT"},
+	{key: "base_url", flag: "base-url", env: baseURLEnv},
+	{key: "min_interval", flag: "min-interval", env: "AOC_MIN_INTERVAL"},
+	{key: "session", env: sessionEnv},
+}
+
+// configFile is a parsed configuration file
+type configFile struct {
+	path   string
+	values map[string]any
+	// days holds the per-day parameters, passed to solvers implementing Configurable
+	days map[int]map[string]string
+}
+
+// configFiles holds the configuration files found for this run, by decreasing precedence
+var configFiles []configFile
+
+// dayParams holds the merged per-day parameters of the configuration files
+var dayParams = map[int]map[string]string{}
+
+// configFilePaths returns the candidate configuration files, by decreasing precedence
+func configFilePaths(cmd *cobra.Command) []string {
+	if explicit, _ := cmd.Flags().GetString("config"); explicit != "" {
+		return []string{explicit}
+	}
+	paths := []string{projectConfigFile}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+	if configHome != "" {
+		paths = append(paths, filepath.Join(configHome, "adventofcode2025", "config.yaml"))
+	}
+	return paths
+}
+
+func loadConfigFile(path string) (configFile, error) {
+	config := configFile{path: path, values: map[string]any{}, days: map[int]map[string]string{}}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	raw := struct {
+		Days   map[int]map[string]any `yaml:"days"`
+		Values map[string]any         `yaml:",inline"`
+	}{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return config, fmt.Errorf("%s: %w", path, err)
+	}
+	for key, value := range raw.Values {
+		if !isSettingKey(key) {
+			return config, fmt.Errorf("%s: unknown setting %q", path, key)
+		}
+		config.values[key] = value
+	}
+	for day, params := range raw.Days {
+		config.days[day] = map[string]string{}
+		for name, value := range params {
+			config.days[day][name] = fmt.Sprint(value)
+		}
+	}
+	return config, nil
+}
+
+func isSettingKey(key string) bool {
+	for _, s := range settings {
+		if s.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// loadConfig reads the configuration files and applies them, along with the environment, to the
+// flags of cmd not given on the command line. It also hands the per-day parameters to the registry.
+func loadConfig(cmd *cobra.Command) error {
+	explicit, _ := cmd.Flags().GetString("config")
+	configFiles = nil
+	for _, path := range configFilePaths(cmd) {
+		config, err := loadConfigFile(path)
+		if errors.Is(err, fs.ErrNotExist) && explicit == "" {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		configFiles = append(configFiles, config)
+	}
+
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		flag := cmd.Flags().Lookup(s.flag)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		value, source := resolveSetting(s, nil)
+		if source == "default" {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid %s from %s: %w", s.key, source, err)
+		}
+	}
+
+	// lower precedence files go first so that higher precedence ones override them
+	dayParams = map[int]map[string]string{}
+	for i := len(configFiles) - 1; i >= 0; i-- {
+		for day, params := range configFiles[i].days {
+			if _, found := registry[day]; !found {
+				return fmt.Errorf("%s: day %d is not registered", configFiles[i].path, day)
+			}
+			if dayParams[day] == nil {
+				dayParams[day] = map[string]string{}
+			}
+			for name, value := range params {
+				dayParams[day][name] = value
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSetting returns the effective value of a setting, and where it comes from.
+// flag is the command line flag of the setting, nil if it isn't available.
+func resolveSetting(s setting, flag *pflag.Flag) (value, source string) {
+	if flag != nil && flag.Changed {
+		return flag.Value.String(), "flag --" + flag.Name
+	}
+	if value := os.Getenv(s.env); value != "" {
+		return value, "env $" + s.env
+	}
+	for _, config := range configFiles {
+		if value, found := config.values[s.key]; found {
+			return fmt.Sprint(value), config.path
+		}
+	}
+	if flag != nil {
+		return flag.DefValue, "default"
+	}
+	return "", "default"
+}
+
+// findFlag looks up a flag in a command or any of its subcommands
+func findFlag(c *cobra.Command, name string) *pflag.Flag {
+	if name == "" {
+		return nil
+	}
+	if flag := c.Flags().Lookup(name); flag != nil {
+		return flag
+	}
+	for _, sub := range c.Commands() {
+		if flag := findFlag(sub, name); flag != nil {
+			return flag
+		}
+	}
+	return nil
+}
+
+// configSetting returns the effective value of a setting without a command line flag
+func configSetting(key string) string {
+	for _, s := range settings {
+		if s.key == key {
+			value, _ := resolveSetting(s, nil)
+			return value
+		}
+	}
+	return ""
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective settings and where each one comes from",
+	Run:   runConfigShow,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SETTING\tVALUE\tSOURCE")
+	for _, s := range settings {
+		// settings of other commands are shown with their defaults
+		flag := cmd.Flags().Lookup(s.flag)
+		if flag == nil {
+			flag = findFlag(cmd.Root(), s.flag)
+		}
+		value, source := resolveSetting(s, flag)
+		if s.key == "session" && value != "" {
+			value = "<hidden>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.key, value, source)
+	}
+
+	days := []int{}
+	for day := range dayParams {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		names := []string{}
+		for name := range dayParams[day] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "days.%s.%s\t%s\t%s\n", strconv.Itoa(day), name, dayParams[day][name], paramSource(day, name))
+		}
+	}
+	w.Flush()
+}
+
+// paramSource returns the configuration file setting a day parameter
+func paramSource(day int, name string) string {
+	for _, config := range configFiles {
+		if _, found := config.days[day][name]; found {
+			return config.path
+		}
+	}
+	return "default"
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestConfigPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "project.yaml")
+	user := filepath.Join(dir, "user.yaml")
+	if err := os.WriteFile(project, []byte("output: json\ndays:\n  3:\n    batteries_part1: 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(user, []byte("output: tsv\ninputs_dir: mine\nlog_level: warn\ndays:\n  3:\n    batteries_part1: 5\n    batteries_part2: 4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { configFiles = nil }()
+	configFiles = nil
+	for _, path := range []string{project, user} {
+		config, err := loadConfigFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		configFiles = append(configFiles, config)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("inputs-dir", defaultInputsDir, "")
+	flags.String("output", "text", "")
+	flags.String("log-level", "info", "")
+	if err := flags.Parse([]string{"--log-level", "debug"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(inputsDirEnv, "from-env")
+
+	for _, tc := range []struct {
+		key, value, source string
+	}{
+		{"log_level", "debug", "flag --log-level"},
+		{"inputs_dir", "from-env", "env $" + inputsDirEnv},
+		{"output", "json", project},
+	} {
+		for _, s := range settings {
+			if s.key != tc.key {
+				continue
+			}
+			value, source := resolveSetting(s, flags.Lookup(s.flag))
+			if value != tc.value || source != tc.source {
+				t.Errorf("%s: got %q from %s, want %q from %s", tc.key, value, source, tc.value, tc.source)
+			}
+		}
+	}
+
+	if got := configFiles[1].days[3]["batteries_part2"]; got != "4" {
+		t.Errorf("got batteries_part2 %q, want 4", got)
+	}
+}
+
+func TestConfigUnknownSetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("input_dir: typo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfigFile(path); err == nil {
+		t.Error("an unknown setting must be rejected")
+	}
+}
@@ -1,39 +1,61 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
-	"os"
-	"strconv"
+	"math/rand/v2"
+	"unicode/utf8"
+
+	"dmedinag/adventofcode2025/parse"
 
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/cobra"
 )
 
-// day1Cmd represents the day1 command
-var day1Cmd = &cobra.Command{
-	Use:   "day1",
-	Short: "Get password to enter safe",
-	Run:   day1run,
+func init() {
+	Register(Day{
+		Number: 1,
+		Title:  "Get password to enter safe",
+		New:    func() Solver { return &day1{} },
+	})
 }
 
-func init() {
-	rootCmd.AddCommand(day1Cmd)
+type day1 struct {
+	instructions []Instruction
 }
 
-func day1run(cmd *cobra.Command, args []string) {
-	followUp, _ := cmd.Flags().GetBool("follow-up")
-	inputFile, _ := cmd.Flags().GetString("input-file")
-	instructions := readRotations(inputFile)
+func (d *day1) Parse(input io.Reader) error {
+	instructions, err := readRotations(input)
+	if err != nil {
+		return err
+	}
 	for _, instr := range instructions {
 		log.Debug().Msg(instr.String())
 	}
+	d.instructions = instructions
+	return nil
+}
 
+func (d *day1) Part1(ctx context.Context) (Answer, error) {
+	password, err := findPassword(ctx, d.instructions, false)
+	return IntAnswer(password), err
+}
+
+func (d *day1) Part2(ctx context.Context) (Answer, error) {
+	password, err := findPassword(ctx, d.instructions, true)
+	return IntAnswer(password), err
+}
+
+func findPassword(ctx context.Context, instructions []Instruction, followUp bool) (int, error) {
 	currentPosition := 50
 	password := 0
 
-	for _, instruction := range instructions {
+	for i, instruction := range instructions {
+		if ctx.Err() != nil {
+			return 0, cancelled(ctx, "applying %d of %d rotations", i, len(instructions))
+		}
 		previousPosition := currentPosition
 		if instruction.rotation == Left {
 			currentPosition -= instruction.distance
@@ -66,7 +88,8 @@ func day1run(cmd *cobra.Command, args []string) {
 		currentPosition %= 100
 
 	}
-	log.Info().Msgf("The password is: %d", password)
+	log.Debug().Msgf("The password is: %d", password)
+	return password, nil
 }
 
 type Rotation int
@@ -85,14 +108,14 @@ func (r Rotation) String() string {
 	}
 }
 
-func RotationFromRune(r rune) Rotation {
+func RotationFromRune(r rune) (Rotation, error) {
 	switch r {
 	case 'L':
-		return Left
+		return Left, nil
 	case 'R':
-		return Right
+		return Right, nil
 	default:
-		panic("invalid rotation rune")
+		return Left, fmt.Errorf("invalid rotation %q", r)
 	}
 }
 
@@ -105,31 +128,51 @@ func (i *Instruction) String() string {
 	return fmt.Sprintf("%v %d", i.rotation, i.distance)
 }
 
-func parseInstruction(s string) Instruction {
-	rotation := RotationFromRune([]rune(s)[0])
-	distance, _ := strconv.Atoi(s[1:])
+func parseInstruction(s parse.Span) (Instruction, error) {
+	if s.Text == "" {
+		return Instruction{}, s.Fail(errors.New("empty instruction"))
+	}
+	r, size := utf8.DecodeRuneInString(s.Text)
+	rotation, err := RotationFromRune(r)
+	if err != nil {
+		return Instruction{}, s.FailAt(0, string(r), err)
+	}
+	distance, err := parse.Int(s.Slice(size, len(s.Text)))
+	if err != nil || distance < 0 {
+		return Instruction{}, s.FailAt(size, s.Text[size:], errors.New("invalid distance"))
+	}
 	return Instruction{
 		rotation: rotation,
 		distance: distance,
-	}
+	}, nil
 }
 
-func readRotations(filename string) []Instruction {
-	file, err := os.Open(filename)
+func readRotations(input io.Reader) ([]Instruction, error) {
+	lines, err := parse.Lines(input)
 	if err != nil {
-		log.Fatal().Err(err).Send()
+		return nil, err
 	}
-	defer file.Close()
-	scanner := bufio.NewScanner(file)
-
-	instructions := []Instruction{}
-
-	for scanner.Scan() {
-		instructions = append(instructions, parseInstruction(scanner.Text()))
+	instructions, err := parse.Each(lines, parseInstruction)
+	if err != nil {
+		return nil, fromParseError(err)
 	}
+	return instructions, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal().Err(err).Send()
+// Generate writes size rotations, of distances from 1 to 999
+func (d *day1) Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error {
+	if err := generatorParams(params, nil); err != nil {
+		return err
+	}
+	if size == 0 {
+		size = 4000
+	}
+	for range size {
+		rotation := 'L'
+		if rng.IntN(2) == 1 {
+			rotation = 'R'
+		}
+		fmt.Fprintf(w, "%c%d\n", rotation, 1+rng.IntN(999))
 	}
-	return instructions
+	return nil
 }
@@ -1,58 +1,77 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
+	"fmt"
+	"io"
 	"math"
-	"os"
+	"math/rand/v2"
 	"strconv"
 	"strings"
 
+	"dmedinag/adventofcode2025/intervals"
+	"dmedinag/adventofcode2025/parse"
+
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/cobra"
 )
 
-// day2Cmd represents the day2 command
-var day2Cmd = &cobra.Command{
-	Use:   "day2",
-	Short: "Invalid ids",
-	Run:   runDay2,
+func init() {
+	Register(Day{
+		Number: 2,
+		Title:  "Invalid ids",
+		New:    func() Solver { return &day2{} },
+	})
 }
 
-func init() {
-	rootCmd.AddCommand(day2Cmd)
+type day2 struct {
+	ranges []intervals.Interval
 }
 
-func runDay2(cmd *cobra.Command, args []string) {
-	inputFile, _ := cmd.Flags().GetString("input-file")
-	isFollowUp, _ := cmd.Flags().GetBool("follow-up")
-	ranges := readIdRanges(inputFile)
-	toWait := len(ranges)
+func (d *day2) Parse(input io.Reader) error {
+	ranges, err := readIdRanges(input)
+	if err != nil {
+		return err
+	}
+	d.ranges = ranges
+	return nil
+}
 
-	exitChan := make(chan int)
+func (d *day2) Part1(ctx context.Context) (Answer, error) {
+	sum, err := sumInvalidIds(ctx, d.ranges, false)
+	return sum.answer(), err
+}
 
-	for _, r := range ranges {
-		if isFollowUp {
-			go reportInvalidIdsAnyChainLength(r, exitChan)
-			// 41823587595 for actual input is a too-high answer
-		} else {
-			go reportInvalidIds(r, exitChan)
-		}
-	}
+func (d *day2) Part2(ctx context.Context) (Answer, error) {
+	sum, err := sumInvalidIds(ctx, d.ranges, true)
+	return sum.answer(), err
+}
 
-	result := 0
+func sumInvalidIds(ctx context.Context, ranges []intervals.Interval, isFollowUp bool) (counter, error) {
+	if isFollowUp {
+		return sumInvalidIdsAnyChainLength(ctx, ranges)
+	}
 
-	for range toWait {
-		result += <-exitChan
+	sums, processed, err := parallelMap(ctx, ranges, func(r intervals.Interval) counter {
+		return sumInvalidIdsInRange(ctx, r)
+	})
+	result := newCounter(0)
+	for _, sum := range sums {
+		result = result.add(sum)
+	}
+	if err != nil {
+		return counter{}, cancelled(ctx, "processing %d of %d ranges, adding up to %v so far", processed, len(ranges), result)
 	}
 
-	log.Info().Msgf("The sum of all invalid ids is %d", result)
+	log.Debug().Msgf("The sum of all invalid ids is %v", result)
+	return result, nil
 }
 
-func reportInvalidIds(r IdRange, exitChan chan int) {
-	sum := 0
+func sumInvalidIdsInRange(ctx context.Context, r intervals.Interval) counter {
+	sum := newCounter(0)
+	found := 0
 	lower := r.Lower
 
-	for {
+	for ctx.Err() == nil {
 		// 1. find first potential invalid id,
 		// i.e. first number in the range with an even number of digits
 		candidate := strconv.Itoa(lower)
@@ -67,63 +86,86 @@ func reportInvalidIds(r IdRange, exitChan chan int) {
 
 		// 3. determine whether the target invalid id is within range (lower <= target <= upper)
 
-		candidateInt, _ := strconv.Atoi(candidate)
-		if candidateInt > r.Upper {
+		// a candidate too large for an int is beyond any upper bound
+		candidateInt, err := strconv.Atoi(candidate)
+		if err != nil || candidateInt > r.Upper {
 			break
 		}
 		if candidateInt >= r.Lower {
 			log.Debug().Str("range", r.String()).Msgf("found invalid id candidate %d", candidateInt)
-			sum += candidateInt
+			sum = sum.add(newCounter(candidateInt))
+			found++
 		}
 		// 4. find next candidate (AB[C+1]AB[C+1]), see if it's within range, abort when it isn't
 		nextHalfCandidateInt, _ := strconv.Atoi(halfCandidate)
 		nextHalfCandidateInt++
 		nextHalfCandidate := strconv.Itoa(nextHalfCandidateInt)
-		nextCandidateInt, _ := strconv.Atoi(nextHalfCandidate + nextHalfCandidate)
-		if nextCandidateInt > r.Upper {
+		nextCandidateInt, err := strconv.Atoi(nextHalfCandidate + nextHalfCandidate)
+		if err != nil || nextCandidateInt > r.Upper {
 			break
 		}
 		lower = nextCandidateInt
 	}
 
-	if sum == 0 {
+	if found == 0 {
 		log.Debug().Msgf("no invalid ids found in range %v", r)
 	}
-	exitChan <- sum
+	return sum
 }
 
-func reportInvalidIdsAnyChainLength(r IdRange, exitChan chan int) {
-	upperLen := len(strconv.Itoa(r.Upper))
-	subExitChan := make(chan []int)
-	toWait := 0
-	found := map[int]bool{}
+// chainSearch looks for the invalid ids of a range made of a chain of the target length, repeated
+type chainSearch struct {
+	rangeIndex int
+	r          intervals.Interval
+	target     int
+}
 
-	for i := range upperLen / 2 {
-		go reportInvalidIdsForTargetChainLength(r, i+1, subExitChan)
-		toWait++
-	}
-	for range toWait {
-		for _, invalidId := range <-subExitChan {
-			found[invalidId] = true
+func sumInvalidIdsAnyChainLength(ctx context.Context, ranges []intervals.Interval) (counter, error) {
+	searches := []chainSearch{}
+	for i, r := range ranges {
+		upperLen := len(strconv.Itoa(r.Upper))
+		for target := range upperLen / 2 {
+			searches = append(searches, chainSearch{rangeIndex: i, r: r, target: target + 1})
 		}
 	}
 
-	result := 0
-	for invalidId := range found {
-		result += invalidId
+	invalidIds, processed, err := parallelMap(ctx, searches, func(s chainSearch) []int {
+		return findInvalidIdsForTargetChainLength(ctx, s.r, s.target)
+	})
+
+	// an id may be made of chains of several lengths (e.g. 111111), but it must be counted once per range
+	found := make([]map[int]bool, len(ranges))
+	for i, ids := range invalidIds {
+		rangeIndex := searches[i].rangeIndex
+		if found[rangeIndex] == nil {
+			found[rangeIndex] = map[int]bool{}
+		}
+		for _, invalidId := range ids {
+			found[rangeIndex][invalidId] = true
+		}
+	}
+	result := newCounter(0)
+	for _, ids := range found {
+		for invalidId := range ids {
+			result = result.add(newCounter(invalidId))
+		}
+	}
+	if err != nil {
+		return counter{}, cancelled(ctx, "processing %d of %d chain lengths across %d ranges, adding up to %v so far", processed, len(searches), len(ranges), result)
 	}
 
-	exitChan <- result
+	log.Debug().Msgf("The sum of all invalid ids is %v", result)
+	return result, nil
 }
 
-func reportInvalidIdsForTargetChainLength(r IdRange, target int, exitChan chan []int) {
+func findInvalidIdsForTargetChainLength(ctx context.Context, r intervals.Interval, target int) []int {
 	result := []int{}
 
 	lowerAsStr := strconv.Itoa(r.Lower)
 
 	upperAsStr := strconv.Itoa(r.Upper)
 
-	lenBounds := IdRange{
+	lenBounds := intervals.Interval{
 		Lower: len(lowerAsStr),
 		Upper: len(upperAsStr),
 	}
@@ -152,14 +194,14 @@ func reportInvalidIdsForTargetChainLength(r IdRange, target int, exitChan chan [
 				}
 				maxChainInt, _ := strconv.Atoi(maxChain)
 
-				for {
+				for ctx.Err() == nil {
 					potentialId := strings.Repeat(targetChain, reps)
-					potentialIdInt, _ := strconv.Atoi(potentialId)
-					if potentialIdInt > r.Upper {
+					potentialIdInt, err := strconv.Atoi(potentialId)
+					if err != nil || potentialIdInt > r.Upper {
 						logger.Debug().Msgf("Potential id %d exceeds upper bound. No more invalid ids of length %d with target chain length", potentialIdInt, targetIdLen)
 						break
 					}
-					if r.contains(potentialIdInt) {
+					if r.Contains(potentialIdInt) {
 						logger.Debug().Msgf("Invalid id %v found", potentialIdInt)
 						result = append(result, potentialIdInt)
 					}
@@ -177,54 +219,48 @@ func reportInvalidIdsForTargetChainLength(r IdRange, target int, exitChan chan [
 		logger.Debug().Msgf("No potential candidates for target chain length")
 	}
 
-	exitChan <- result
-}
-
-type IdRange struct {
-	Lower int
-	Upper int
-}
-
-func (r IdRange) String() string {
-	return "[" + strconv.Itoa(r.Lower) + "-" + strconv.Itoa(r.Upper) + "]"
-}
-
-func (r *IdRange) contains(id int) bool {
-	return id >= r.Lower && id <= r.Upper
+	return result
 }
 
-func IdRangeFromString(s string) IdRange {
-	idPair := strings.Split(s, "-")
-	if idPair == nil || len(idPair) != 2 {
-		log.Fatal().Msgf("there's no id range on %q", s)
-	}
-	lower, _ := strconv.Atoi(idPair[0])
-	upper, _ := strconv.Atoi(idPair[1])
-	return IdRange{
-		Lower: lower,
-		Upper: upper,
-	}
-}
-
-func readIdRanges(filename string) []IdRange {
-	file, err := os.Open(filename)
+// readIdRanges reads the comma separated ranges of ids as given: an id in two overlapping ranges counts in both
+func readIdRanges(input io.Reader) ([]intervals.Interval, error) {
+	lines, err := parse.Lines(input)
 	if err != nil {
-		log.Fatal().Err(err).Send()
+		return nil, err
 	}
-	defer file.Close()
-	scanner := bufio.NewScanner(file)
-
-	ranges := []IdRange{}
 
-	for scanner.Scan() {
-		rangeStrings := strings.Split(scanner.Text(), ",")
-		for _, r := range rangeStrings {
-			ranges = append(ranges, IdRangeFromString(r))
+	ranges := []intervals.Interval{}
+	for _, line := range lines {
+		lineRanges, err := parse.Each(parse.Split(line, ","), parse.Range)
+		if err != nil {
+			return nil, fromParseError(err)
 		}
+		ranges = append(ranges, lineRanges...)
 	}
+	return ranges, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal().Err(err).Send()
+// Generate writes size disjoint ranges of ids of up to 10 digits, in no particular order
+func (d *day2) Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error {
+	if err := generatorParams(params, nil); err != nil {
+		return err
+	}
+	if size == 0 {
+		size = 35
+	}
+	taken := intervals.Set{}
+	ranges := make([]string, 0, size)
+	for len(ranges) < size {
+		// ids of every length are as likely, ranges spanning up to 5% of their lower id
+		magnitude := int(math.Pow10(rng.IntN(10)))
+		lower := magnitude + rng.IntN(9*magnitude)
+		r := intervals.Interval{Lower: lower, Upper: lower + rng.IntN(lower/20+10)}
+		if taken.Overlaps(r) {
+			continue
+		}
+		taken.Insert(r)
+		ranges = append(ranges, fmt.Sprintf("%d-%d", r.Lower, r.Upper))
 	}
-	return ranges
+	fmt.Fprintln(w, strings.Join(ranges, ","))
+	return nil
 }
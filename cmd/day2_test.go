@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDay2OverlappingRanges(t *testing.T) {
+	d, _ := LookupDay(2)
+	// 22 is in both ranges, and counts in each of them
+	solutions, err := d.Solve(context.Background(), strings.NewReader("11-22,15-30\n"), 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, solution := range solutions {
+		if solution.Err != nil || !solution.Answer.Equal(IntAnswer(11+22+22)) {
+			t.Errorf("part %d: got (%v, %v), want 55", solution.Part, solution.Answer, solution.Err)
+		}
+	}
+}
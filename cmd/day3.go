@@ -1,109 +1,171 @@
 package cmd
 
 import (
-	"bufio"
-	"math"
-	"os"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+
+	"dmedinag/adventofcode2025/parse"
 
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/cobra"
 )
 
-// day3Cmd represents the day3 command
-var day3Cmd = &cobra.Command{
-	Use:   "day3",
-	Short: "Broken elevators, maximum joltage",
-	Run:   day3Run,
-}
-
 func init() {
-	rootCmd.AddCommand(day3Cmd)
+	Register(Day{
+		Number: 3,
+		Title:  "Broken elevators, maximum joltage",
+		New:    func() Solver { return &day3{batteries: [2]int{2, 12}} },
+	})
 }
 
-func day3Run(cmd *cobra.Command, args []string) {
-	inputFile, _ := cmd.Flags().GetString("input-file")
-	isFollowUp, _ := cmd.Flags().GetBool("follow-up")
+type day3 struct {
+	banks [][]int
+	// batteries activated per bank in each part
+	batteries [2]int
+}
 
-	banks := readBatteryBanks(inputFile)
+// Configure accepts batteries_part1 and batteries_part2, the number of batteries to activate per bank
+func (d *day3) Configure(params map[string]string) error {
+	for name, value := range params {
+		part := 0
+		switch name {
+		case "batteries_part1":
+			part = 1
+		case "batteries_part2":
+			part = 2
+		default:
+			return fmt.Errorf("unknown parameter %q", name)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid %s %q, expected a positive number", name, value)
+		}
+		d.batteries[part-1] = n
+	}
+	return nil
+}
 
+func (d *day3) Parse(input io.Reader) error {
+	banks, err := readBatteryBanks(input)
+	if err != nil {
+		return err
+	}
 	for i, b := range banks {
 		log.Debug().Msgf("Bank %d: %v", i, b)
 	}
-	commsChan := make(chan int)
+	d.banks = banks
+	return nil
+}
 
-	var nBatteries int
-	if isFollowUp {
-		nBatteries = 12
-	} else {
-		nBatteries = 2
-	}
+func (d *day3) Part1(ctx context.Context) (Answer, error) {
+	joltage, err := totalJoltage(ctx, d.banks, d.batteries[0])
+	return joltage.answer(), err
+}
 
-	for _, bank := range banks {
-		go reportMaxBankJoltageNBatteries(bank, nBatteries, commsChan)
-	}
+func (d *day3) Part2(ctx context.Context) (Answer, error) {
+	joltage, err := totalJoltage(ctx, d.banks, d.batteries[1])
+	return joltage.answer(), err
+}
 
-	joltage := 0
-	for range len(banks) * nBatteries {
-		joltage += <-commsChan
+func totalJoltage(ctx context.Context, banks [][]int, nBatteries int) (counter, error) {
+	for i, bank := range banks {
+		if len(bank) < nBatteries {
+			return counter{}, fmt.Errorf("bank %d has %d batteries, can't activate %d", i+1, len(bank), nBatteries)
+		}
 	}
-	log.Info().Msgf("Total joltage using max %d batteries per bank: %d", nBatteries, joltage)
-}
 
-func readBatteryBanks(filename string) [][]int {
-	file, err := os.Open(filename)
+	joltages, processed, err := parallelMap(ctx, banks, func(bank []int) counter {
+		return maxBankJoltage(bank, nBatteries)
+	})
+	joltage := newCounter(0)
+	for _, j := range joltages {
+		joltage = joltage.add(j)
+	}
 	if err != nil {
-		log.Fatal().Err(err).Send()
+		return counter{}, cancelled(ctx, "processing %d of %d banks, with %v total joltage so far", processed, len(banks), joltage)
 	}
-	defer file.Close()
-	scanner := bufio.NewScanner(file)
-
-	batteryBanks := [][]int{}
+	log.Debug().Msgf("Total joltage using max %d batteries per bank: %v", nBatteries, joltage)
+	return joltage, nil
+}
 
-	for scanner.Scan() {
-		batteryBanks = append(batteryBanks, parseBatteryBank(scanner.Bytes()))
+func readBatteryBanks(input io.Reader) ([][]int, error) {
+	lines, err := parse.Lines(input)
+	if err != nil {
+		return nil, err
 	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal().Err(err).Send()
+	batteryBanks, err := parse.Each(lines, func(s parse.Span) ([]int, error) {
+		return parse.Runes(s, batteryJoltage)
+	})
+	if err != nil {
+		return nil, fromParseError(err)
 	}
-	return batteryBanks
+	return batteryBanks, nil
 }
 
-func parseBatteryBank(text []byte) []int {
-	bank := make([]int, len(text))
-	for i, b := range text {
-		// ascii numbers start at 48
-		bank[i] = int(b) - 48
+// batteryJoltage reads the joltage of a battery, a single digit
+func batteryJoltage(r rune) (int, error) {
+	joltage, err := parse.Digit(r)
+	if err != nil {
+		return 0, errors.New("invalid battery joltage")
 	}
-	return bank
+	return joltage, nil
 }
 
-func reportMaxBankJoltageNBatteries(bank []int, nBatteries int, c chan int) {
+func maxBankJoltage(bank []int, nBatteries int) counter {
 	// for a bank with batteries b1, b2, ..., bN, find the max across b0... b(N-nBatteries)
 	// For example for a bank consisting of batteries b0,b1,b2,b3 (N=4); and nBatteries=2,
 	// the first battery to be activated _must be_ within {b0,b1,b2} so that there exists a second battery to be activated
 	// Once the first battery has been found, we should repeat the process to find the rest of batteries.
-	// If the first activated battery is bX, we'll call this same function recursively on b(X+1)..bN with nBatteries-1
+	// If the first activated battery is bX, we'll repeat the search on b(X+1)..bN with nBatteries-1
 	// until there are no more batteries left to activate
-
-	relevantBatteries := bank[:len(bank)-nBatteries+1]
-	maxIndex := -1
-	maxValue := -1
-	for i, b := range relevantBatteries {
-		if b == 9 {
-			maxIndex = i
-			maxValue = b
-			break
-		}
-		if b > maxValue {
-			maxIndex = i
-			maxValue = b
+	joltage, ten := newCounter(0), newCounter(10)
+	for selected := 1; nBatteries > 0; selected++ {
+		relevantBatteries := bank[:len(bank)-nBatteries+1]
+		maxIndex := -1
+		maxValue := -1
+		for i, b := range relevantBatteries {
+			if b == 9 {
+				maxIndex = i
+				maxValue = b
+				break
+			}
+			if b > maxValue {
+				maxIndex = i
+				maxValue = b
+			}
 		}
+		log.Debug().Msgf("Selected #%d battery %d @ %d from bank %v", selected, maxValue, maxIndex, bank)
+		joltage = joltage.mul(ten).add(newCounter(maxValue))
+		bank = bank[maxIndex+1:]
+		nBatteries--
+	}
+	return joltage
+}
+
+// Generate writes size banks of batteries of joltages 1 to 9, taking length as the batteries per bank,
+// which can't be fewer than those activated in either part
+func (d *day3) Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error {
+	activated := max(d.batteries[0], d.batteries[1])
+	length := max(100, activated)
+	if err := generatorParams(params, map[string]any{"length": &length}); err != nil {
+		return err
+	}
+	if length < activated {
+		return fmt.Errorf("invalid length %d, expected at least the %d batteries activated per bank", length, activated)
 	}
-	log.Debug().Msgf("Selected #%d battery %d @ %d from bank %v", 3-nBatteries, maxValue, maxIndex, bank)
-	if nBatteries > 1 {
-		go reportMaxBankJoltageNBatteries(bank[maxIndex+1:], nBatteries-1, c)
+	if size == 0 {
+		size = 200
+	}
+	bank := make([]byte, length+1)
+	bank[length] = '\n'
+	for range size {
+		for i := range length {
+			bank[i] = byte('1' + rng.IntN(9))
+		}
+		w.Write(bank)
 	}
-	joltage := maxValue * int(math.Pow10(nBatteries-1))
-	c <- joltage
+	return nil
 }
@@ -1,222 +1,163 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"math/rand/v2"
+	"sort"
 	"strconv"
+	"strings"
+
+	"dmedinag/adventofcode2025/grid"
+	"dmedinag/adventofcode2025/parse"
 
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/cobra"
 )
 
-// day4Cmd represents the day4 command
-var day4Cmd = &cobra.Command{
-	Use:   "day4",
-	Short: "Accessible rolls",
-	Run:   runDay4,
-}
-
 func init() {
-	rootCmd.AddCommand(day4Cmd)
-
-	// Here you will define your flags and configuration settings.
-
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// day4Cmd.PersistentFlags().String("foo", "", "A help for foo")
-
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// day4Cmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	Register(Day{
+		Number: 4,
+		Title:  "Accessible rolls",
+		New:    func() Solver { return &day4{} },
+	})
 }
 
-func runDay4(cmd *cobra.Command, args []string) {
-	inputFile, _ := cmd.Flags().GetString("input-file")
-	isFollowUp, _ := cmd.Flags().GetBool("follow-up")
-
-	if isFollowUp {
-		rollMap := readMap(inputFile)
-		registerNeighbors(&(rollMap.Rolls))
-		accessibleRolls := findAccessibleRolls(&rollMap)
-		log.Info().Msgf("There are %d accessible rolls in the map", len(accessibleRolls))
-	} else {
-		base(inputFile)
-	}
+type day4 struct {
+	// floor is true where there's a roll
+	floor *grid.Dense[bool]
+	// explorer is set up by the first repl command
+	explorer *rollMapExplorer
 }
 
-func base(inputFile string) {
-	file, err := os.Open(inputFile)
+func (d *day4) Parse(input io.Reader) error {
+	lines, err := parse.Lines(input)
 	if err != nil {
-		log.Fatal().Err(err).Send()
+		return err
 	}
-	defer file.Close()
-	scanner := bufio.NewScanner(file)
-
-	rows := make([][]rune, 3)
-
-	filledRows := 0
-	middleRowIndex := 1
+	floor, err := parse.Grid(lines, decodeMapElement)
+	if err != nil {
+		return fromParseError(err)
+	}
+	d.floor = floor
+	return nil
+}
 
-	c := make(chan int)
-	toWait := 0
+func (d *day4) Part1(ctx context.Context) (Answer, error) {
+	accessibleRolls, err := base(ctx, d.floor)
+	return IntAnswer(accessibleRolls), err
+}
 
-	for scanner.Scan() {
-		toWait++
-		rows[(middleRowIndex-1)%3] = []rune(scanner.Text())
-		filledRows++
-		middleRowIndex++
-		if filledRows < 2 {
-			// until we have at least 2 rows we can't assess much
-			continue
-		}
-		if filledRows == 2 {
-			// special case: first row (no rolls above)
-			go countAccessibleRolls(nil, rows[0], rows[1], c, toWait-2)
-			continue
-		}
-		go countAccessibleRolls(rows[(middleRowIndex-1)%3], rows[middleRowIndex%3], rows[(middleRowIndex+1)%3], c, toWait-2)
+func (d *day4) Part2(ctx context.Context) (Answer, error) {
+	rollMap, err := readMap(ctx, d.floor)
+	if err != nil {
+		return Answer{}, err
 	}
+	registerNeighbors(rollMap.Rolls)
+	accessibleRolls, err := findAccessibleRolls(ctx, &rollMap)
+	if err != nil {
+		return Answer{}, err
+	}
+	log.Debug().Msgf("There are %d accessible rolls in the map", len(accessibleRolls))
+	return IntAnswer(len(accessibleRolls)), nil
+}
 
-	// special case: last row (no rolls below)
-	go countAccessibleRolls(rows[(middleRowIndex)%3], rows[(middleRowIndex+1)%3], nil, c, toWait-1)
+// decodeMapElement accepts only empty spaces and rolls
+func decodeMapElement(_ grid.Point, r rune) (bool, bool, error) {
+	switch r {
+	case '.':
+		return false, true, nil
+	case '@':
+		return true, true, nil
+	}
+	return false, false, errors.New("unknown map element")
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal().Err(err).Send()
+// rowIndexes returns the indexes of the rows of a grid, to spread them on the workers pool
+func rowIndexes[T any](g grid.Grid[T]) []int {
+	indexes := make([]int, g.Rows())
+	for i := range indexes {
+		indexes[i] = i
 	}
+	return indexes
+}
 
+func base(ctx context.Context, floor grid.Grid[bool]) (int, error) {
+	counts, counted, err := parallelMap(ctx, rowIndexes(floor), func(row int) int {
+		return countAccessibleRolls(floor, row)
+	})
 	accessibleRolls := 0
-	for range toWait {
-		accessibleRolls += <-c
+	for _, rowRolls := range counts {
+		accessibleRolls += rowRolls
+	}
+	if err != nil {
+		return 0, cancelled(ctx, "counting %d of %d rows, %d accessible rolls so far", counted, floor.Rows(), accessibleRolls)
 	}
 
-	log.Info().Msgf("There are %d accessible rolls in the map", accessibleRolls)
+	log.Debug().Msgf("There are %d accessible rolls in the map", accessibleRolls)
+	return accessibleRolls, nil
 }
 
-func countAccessibleRolls(prev, cur, next []rune, c chan int, rowIdx int) {
+func countAccessibleRolls(floor grid.Grid[bool], row int) int {
 	accessibleRolls := 0
-	if prev == nil {
-		prev = make([]rune, len(cur))
-		for i := range len(cur) {
-			prev[i] = '.'
-		}
-	}
-	if next == nil {
-		next = make([]rune, len(cur))
-		for i := range len(cur) {
-			next[i] = '.'
+	for col, roll := range grid.Row(floor, row) {
+		if roll && isRollAccessible(floor, grid.Point{Row: row, Col: col}) {
+			accessibleRolls++
 		}
 	}
-	for i, r := range cur {
-		switch r {
-		case '.': // empty space, nothing to check
-			continue
-		case '@': // roll, check if it's accessible
-			rollPrev, rollCur, rollNext := []rune{}, []rune{}, []rune{}
-
-			if i == 0 {
-				rollPrev = append(rollPrev, '.')
-				rollCur = append(rollCur, '.')
-				rollNext = append(rollNext, '.')
-			} else {
-				rollPrev = append(rollPrev, prev[i-1])
-				rollCur = append(rollCur, cur[i-1])
-				rollNext = append(rollNext, next[i-1])
-			}
-			rollPrev = append(rollPrev, prev[i])
-			rollCur = append(rollCur, cur[i])
-			rollNext = append(rollNext, next[i])
-			if i == len(cur)-1 {
-				rollPrev = append(rollPrev, '.')
-				rollCur = append(rollCur, '.')
-				rollNext = append(rollNext, '.')
-			} else {
-				rollPrev = append(rollPrev, prev[i+1])
-				rollCur = append(rollCur, cur[i+1])
-				rollNext = append(rollNext, next[i+1])
-			}
-			if isRollAccessible(rollPrev, rollCur, rollNext) {
-				accessibleRolls++
-			}
-		default:
-			panic(fmt.Sprintf("what is this? I don't know what %c is", r))
-		}
-	}
-	log.Trace().Msgf("Found %v accessible rolls in row %v:\n%c\n%c\n%c", accessibleRolls, rowIdx, prev, cur, next)
-	c <- accessibleRolls
+	log.Trace().Msgf("Found %v accessible rolls in row %v", accessibleRolls, row)
+	return accessibleRolls
 }
 
-func isRollAccessible(prev, cur, next []rune) bool {
+// isRollAccessible tells whether fewer than 4 rolls surround the position
+func isRollAccessible(floor grid.Grid[bool], p grid.Point) bool {
 	adjacentRolls := 0
-	for i := range 3 {
-		if prev[i] == '@' {
-			adjacentRolls++
-		}
-		if next[i] == '@' {
-			adjacentRolls++
-		}
-		if i != 1 && cur[i] == '@' {
+	for n := range grid.Neighbors8(floor, p) {
+		if roll, _ := floor.Get(n); roll {
 			adjacentRolls++
 		}
 	}
 	return adjacentRolls < 4
 }
 
-func readMap(inputFile string) RollMap {
-	result := map[Coordinates]*Roll{}
+func readMap(ctx context.Context, floor grid.Grid[bool]) (RollMap, error) {
+	result := grid.NewSparse[*Roll](floor.Rows(), floor.Cols())
 
-	file, err := os.Open(inputFile)
+	rows, read, err := parallelMap(ctx, rowIndexes(floor), func(row int) []Roll {
+		return parseRollsFromRow(floor, row)
+	})
 	if err != nil {
-		log.Fatal().Err(err).Send()
-	}
-	defer file.Close()
-	scanner := bufio.NewScanner(file)
-
-	c := make(chan []Roll)
-	rowCount := 0
-	var colCount int
-
-	for scanner.Scan() {
-		colCount = len(scanner.Text())
-		go parseRollsFromRow(rowCount, scanner.Text(), c)
-		rowCount++
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal().Err(err).Send()
+		return RollMap{}, cancelled(ctx, "reading %d of %d rows of the map", read, floor.Rows())
 	}
 
 	log.Trace().Msg("Found rolls on the following coordinates:")
-	for range rowCount {
-		for _, r := range <-c {
-			result[r.Position] = &r
-			log.Trace().Msgf("(%d, %d)\n", r.Position.Row, r.Position.Col)
+	for _, rolls := range rows {
+		for _, r := range rolls {
+			result.Set(r.Position, &r)
+			log.Trace().Msgf("%v\n", r.Position)
 		}
 	}
 
-	return RollMap{
-		Rows:  rowCount,
-		Cols:  colCount,
-		Rolls: result,
-	}
+	return RollMap{Rolls: result}, nil
 }
 
-func parseRollsFromRow(row int, rawElements string, c chan []Roll) {
+func parseRollsFromRow(floor grid.Grid[bool], row int) []Roll {
 	rolls := []Roll{}
-	for col, r := range []rune(rawElements) {
-		if r == '@' {
+	for col, roll := range grid.Row(floor, row) {
+		if roll {
 			rolls = append(rolls, Roll{
-				Position: Coordinates{Row: row, Col: col},
+				Position: grid.Point{Row: row, Col: col},
 			})
 		}
 	}
-	c <- rolls
+	return rolls
 }
 
-func registerNeighbors(rolls *map[Coordinates]*Roll) {
-	for pos, r := range *rolls {
-		for _, target := range pos.Adjacent() {
-			if other, found := (*rolls)[target]; found {
+func registerNeighbors(rolls *grid.Sparse[*Roll]) {
+	for pos, r := range rolls.All() {
+		for target := range grid.Neighbors8(rolls, pos) {
+			if other, found := rolls.Get(target); found {
 				log.Trace().Msgf("%v and %v found as neighbors", pos, target)
 				other.registerNeighbor(r)
 				r.registerNeighbor(other)
@@ -225,61 +166,69 @@ func registerNeighbors(rolls *map[Coordinates]*Roll) {
 	}
 }
 
-func findAccessibleRolls(rollMap *RollMap) []*Roll {
-	accessibleRollsSet := map[Coordinates]*Roll{}
-	candidates := []*Roll{}
-
-	for _, r := range (*rollMap).Rolls {
-		candidates = append(candidates, r)
-	}
+func findAccessibleRolls(ctx context.Context, rollMap *RollMap) ([]*Roll, error) {
+	accessibleRollsSet := map[grid.Point]*Roll{}
+	candidates := rollMap.candidates()
 
 	log.Debug().Msgf("Got %v candidates to assess, map:\n%v", len(candidates), *rollMap)
 
-	for len(candidates) > 0 {
-		nextCandidates := map[Coordinates]*Roll{}
-		for _, roll := range candidates {
-			log.Trace().Msgf("Assessing roll at %v, with neighbors:", roll.Position)
-			for _, n := range roll.neighbors {
-				if !n.IsRemoved() {
-					log.Trace().Msgf(" - %v", n.Position)
-				}
-			}
-			if roll.tryRemove() {
-				log.Debug().Msgf("Roll at %v is accessible, map:\n%vn", roll.Position, *rollMap)
-				accessibleRollsSet[roll.Position] = roll
-				for c, r := range roll.neighbors {
-					if !r.IsRemoved() {
-						log.Trace().Msgf("Registering neighbor at %v as candidate for next round", c)
-						nextCandidates[c] = r
-					} else {
-						log.Trace().Msgf("Neighbor at %v is already removed, skipping", c)
-					}
-				}
-			} else {
-				log.Trace().Msgf("Roll at %v is not accessible", roll.Position)
-			}
+	for round := 0; len(candidates) > 0; round++ {
+		if ctx.Err() != nil {
+			return nil, cancelled(ctx, "%d rounds, with %d rolls removed so far", round, len(accessibleRollsSet))
 		}
-		candidates = []*Roll{}
-		for _, r := range nextCandidates {
-			candidates = append(candidates, r)
+		var removed []*Roll
+		removed, candidates = removalRound(rollMap, candidates)
+		for _, roll := range removed {
+			accessibleRollsSet[roll.Position] = roll
 		}
 	}
 	accessibleRolls := []*Roll{}
 	for _, r := range accessibleRollsSet {
 		accessibleRolls = append(accessibleRolls, r)
 	}
-	return accessibleRolls
+	return accessibleRolls, nil
+}
+
+// removalRound tries to remove every candidate, returning the removed rolls and the candidates for the next round
+func removalRound(rollMap *RollMap, candidates []*Roll) (removed, nextCandidates []*Roll) {
+	nextCandidatesSet := map[grid.Point]*Roll{}
+	for _, roll := range candidates {
+		log.Trace().Msgf("Assessing roll at %v, with neighbors:", roll.Position)
+		for _, n := range roll.neighbors {
+			if !n.IsRemoved() {
+				log.Trace().Msgf(" - %v", n.Position)
+			}
+		}
+		if roll.tryRemove() {
+			log.Debug().Msgf("Roll at %v is accessible, map:\n%vn", roll.Position, *rollMap)
+			removed = append(removed, roll)
+			for c, r := range roll.neighbors {
+				if !r.IsRemoved() {
+					log.Trace().Msgf("Registering neighbor at %v as candidate for next round", c)
+					nextCandidatesSet[c] = r
+				} else {
+					log.Trace().Msgf("Neighbor at %v is already removed, skipping", c)
+				}
+			}
+		} else {
+			log.Trace().Msgf("Roll at %v is not accessible", roll.Position)
+		}
+	}
+	for _, r := range nextCandidatesSet {
+		nextCandidates = append(nextCandidates, r)
+	}
+	return removed, nextCandidates
 }
 
 type Roll struct {
-	Position  Coordinates
-	neighbors map[Coordinates]*Roll
+	Position  grid.Point
+	neighbors map[grid.Point]*Roll
 	removed   bool
 }
 
 func (r Roll) String() string {
 	if r.neighbors == nil {
-		r.neighbors = make(map[Coordinates]*Roll)
+		r.neighbors = make(map[grid.Point]*Roll)
 	}
 	return fmt.Sprintf("%v roll@%v with %d neighbors", r.removed, r.Position, len(r.neighbors))
 }
@@ -312,67 +261,209 @@ func (r *Roll) tryRemove() bool {
 
 func (r *Roll) registerNeighbor(other *Roll) {
 	if r.neighbors == nil {
-		r.neighbors = make(map[Coordinates]*Roll, 8)
+		r.neighbors = make(map[grid.Point]*Roll, 8)
 		return
 	}
 	r.neighbors[other.Position] = other
 }
 
-type Coordinates struct {
-	Row int
-	Col int
+type RollMap struct {
+	Rolls *grid.Sparse[*Roll]
 }
 
-func (c Coordinates) String() string {
-	return fmt.Sprintf("(%d, %d)", c.Row, c.Col)
+// candidates returns every roll of the map, for the first removal round
+func (m RollMap) candidates() []*Roll {
+	candidates := make([]*Roll, 0, m.Rolls.Len())
+	for _, r := range m.Rolls.All() {
+		candidates = append(candidates, r)
+	}
+	return candidates
 }
 
-func (c Coordinates) Adjacent() []Coordinates {
-	result := []Coordinates{
-		{Row: c.Row + 1, Col: c.Col + 1},
-		{Row: c.Row, Col: c.Col + 1},
-		{Row: c.Row + 1, Col: c.Col},
-	}
-	if c.Row > 0 {
-		result = append(result, Coordinates{Row: c.Row - 1, Col: c.Col})
-		result = append(result, Coordinates{Row: c.Row - 1, Col: c.Col + 1})
-	}
-	if c.Col > 0 {
-		result = append(result, Coordinates{Row: c.Row, Col: c.Col - 1})
-		result = append(result, Coordinates{Row: c.Row + 1, Col: c.Col - 1})
+func (m RollMap) String() string {
+	var s strings.Builder
+	s.WriteString("  ")
+	for y := range m.Rolls.Cols() {
+		s.WriteString(strconv.Itoa(y))
 	}
-	if c.Row > 0 && c.Col > 0 {
-		result = append(result, Coordinates{Row: c.Row - 1, Col: c.Col - 1})
+	s.WriteString("\n")
+	var rows strings.Builder
+	grid.Render(&rows, m.Rolls, func(_ grid.Point, r *Roll, found bool) rune {
+		switch {
+		case !found:
+			return '.'
+		case r.removed:
+			return 'x'
+		}
+		return '@'
+	})
+	for x, row := range strings.SplitAfter(rows.String(), "\n") {
+		if row != "" {
+			s.WriteString(strconv.Itoa(x) + " " + row)
+		}
 	}
-	return result
+	return s.String()
 }
 
-type RollMap struct {
-	Rows  int
-	Cols  int
-	Rolls map[Coordinates]*Roll
+// rollMapExplorer is the repl state of day 4, removing the accessible rolls one round at a time
+type rollMapExplorer struct {
+	rollMap    RollMap
+	candidates []*Roll
+	round      int
+	removed    int
 }
 
-func (m RollMap) String() string {
-	s := "  "
-	for y := range m.Cols {
-		s += strconv.Itoa(y)
-	}
-	s += "\n"
-	for x := range m.Rows {
-		s += strconv.Itoa(x) + " "
-		for y := range m.Cols {
-			if r, found := m.Rolls[Coordinates{Row: x, Col: y}]; found {
-				if r.removed {
-					s += "x"
-				} else {
-					s += "@"
+func (d *day4) ExploreCommands() []string {
+	return []string{
+		"show               print the map, with removed rolls as x",
+		"step [n]           run the next n rounds of removals (default 1)",
+		"neighbors <r> <c>  list the neighbors of the roll at row r, column c",
+	}
+}
+
+func (d *day4) Explore(ctx context.Context, w io.Writer, command string, args []string) error {
+	if d.explorer == nil {
+		rollMap, err := readMap(ctx, d.floor)
+		if err != nil {
+			return err
+		}
+		registerNeighbors(rollMap.Rolls)
+		d.explorer = &rollMapExplorer{rollMap: rollMap, candidates: rollMap.candidates()}
+	}
+	e := d.explorer
+
+	switch command {
+	case "show":
+		fmt.Fprint(w, e.rollMap)
+		fmt.Fprintf(w, "round %d: %d of %d rolls removed, %d candidates left\n", e.round, e.removed, e.rollMap.Rolls.Len(), len(e.candidates))
+	case "step":
+		rounds, err := replCount(args)
+		if err != nil {
+			return err
+		}
+		for range rounds {
+			if len(e.candidates) == 0 {
+				fmt.Fprintln(w, "no candidates left, every accessible roll has been removed")
+				break
+			}
+			if ctx.Err() != nil {
+				return cancelled(ctx, "%d rounds", e.round)
+			}
+			var removed []*Roll
+			wasRemoved := map[grid.Point]bool{}
+			for _, r := range e.candidates {
+				wasRemoved[r.Position] = r.IsRemoved()
+			}
+			removed, e.candidates = removalRound(&e.rollMap, e.candidates)
+			newlyRemoved := 0
+			for _, r := range removed {
+				if !wasRemoved[r.Position] {
+					newlyRemoved++
 				}
+			}
+			e.round++
+			e.removed += newlyRemoved
+			fmt.Fprintf(w, "round %d: removed %d rolls, %d candidates for the next round\n", e.round, newlyRemoved, len(e.candidates))
+		}
+	case "neighbors":
+		if len(args) != 2 {
+			return errors.New("usage: neighbors <r> <c>")
+		}
+		row, rowErr := strconv.Atoi(args[0])
+		col, colErr := strconv.Atoi(args[1])
+		if rowErr != nil || colErr != nil {
+			return errors.New("usage: neighbors <r> <c>")
+		}
+		roll, found := e.rollMap.Rolls.Get(grid.Point{Row: row, Col: col})
+		if !found {
+			return fmt.Errorf("no roll at %v", grid.Point{Row: row, Col: col})
+		}
+		positions := []grid.Point{}
+		for pos := range roll.neighbors {
+			positions = append(positions, pos)
+		}
+		sort.Slice(positions, func(i, j int) bool {
+			return positions[i].Row < positions[j].Row || (positions[i].Row == positions[j].Row && positions[i].Col < positions[j].Col)
+		})
+		left := 0
+		for _, pos := range positions {
+			state := "roll"
+			if roll.neighbors[pos].IsRemoved() {
+				state = "removed"
 			} else {
-				s += "."
+				left++
 			}
+			fmt.Fprintf(w, "%v %s\n", pos, state)
 		}
-		s += "\n"
+		fmt.Fprintf(w, "%v\n%d of %d neighbors left, accessible: %v\n", *roll, left, len(positions), left < 4)
+	default:
+		return errUnknownCommand
 	}
-	return s
+	return nil
+}
+
+// Render draws the map as SVG, coloring the rolls by the round they are removed in, dark if they never are
+func (d *day4) Render(ctx context.Context, w io.Writer) error {
+	const cell = 8
+	rollMap, err := readMap(ctx, d.floor)
+	if err != nil {
+		return err
+	}
+	registerNeighbors(rollMap.Rolls)
+
+	removedIn := map[grid.Point]int{}
+	candidates := rollMap.candidates()
+	rounds := 0
+	for round := 1; len(candidates) > 0; round++ {
+		if ctx.Err() != nil {
+			return cancelled(ctx, "%d rounds", round-1)
+		}
+		var removed []*Roll
+		removed, candidates = removalRound(&rollMap, candidates)
+		for _, r := range removed {
+			if _, found := removedIn[r.Position]; !found {
+				removedIn[r.Position] = round
+				rounds = round
+			}
+		}
+	}
+
+	width, height := rollMap.Rolls.Cols()*cell, rollMap.Rolls.Rows()*cell
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="#f4f1ea"/>`)
+	for pos := range rollMap.Rolls.All() {
+		fill, title := "#3b3b3b", "never removed"
+		if round, found := removedIn[pos]; found {
+			// from red for the first round to blue for the last one
+			hue := 0
+			if rounds > 1 {
+				hue = 240 * (round - 1) / (rounds - 1)
+			}
+			fill, title = fmt.Sprintf("hsl(%d, 75%%, 55%%)", hue), fmt.Sprintf("removed in round %d", round)
+		}
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%v %s</title></rect>`, pos.Col*cell+1, pos.Row*cell+1, cell-2, cell-2, fill, pos, title)
+	}
+	fmt.Fprint(w, `</svg>`)
+	return nil
+}
+
+// Generate writes a square map with a side of size positions, taking density as the fraction holding rolls
+func (d *day4) Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error {
+	density := 0.6
+	if err := generatorParams(params, map[string]any{"density": &density}); err != nil {
+		return err
+	}
+	if size == 0 {
+		size = 140
+	}
+	floor := grid.NewDense[bool](size, size)
+	for p := range floor.All() {
+		floor.Set(p, rng.Float64() < density)
+	}
+	return grid.Render(w, floor, func(_ grid.Point, roll, _ bool) rune {
+		if roll {
+			return '@'
+		}
+		return '.'
+	})
 }
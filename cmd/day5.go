@@ -1,180 +1,175 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"os"
-	"sort"
+	"io"
+	"math/rand/v2"
 	"strconv"
-	"strings"
 
-	mapset "github.com/deckarep/golang-set/v2"
+	"dmedinag/adventofcode2025/intervals"
+	"dmedinag/adventofcode2025/parse"
+
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/cobra"
 )
 
-// day5Cmd represents the day5 command
-var day5Cmd = &cobra.Command{
-	Use:   "day5",
-	Short: "fresh products in catalog",
-	Run:   runDay5,
-}
-
 func init() {
-	rootCmd.AddCommand(day5Cmd)
+	Register(Day{
+		Number: 5,
+		Title:  "fresh products in catalog",
+		New:    func() Solver { return &day5{} },
+	})
 }
 
-func runDay5(cmd *cobra.Command, args []string) {
-	inputFile, _ := cmd.Flags().GetString("input-file")
-	isFollowUp, _ := cmd.Flags().GetBool("follow-up")
-
-	intervals, products := parseInput(inputFile)
-
-	if isFollowUp {
-		freshCount := 0
-		for _, i := range intervals.ToSlice() {
-			moreProducts := i.Upper - i.Lower + 1
-			freshCount += moreProducts
-			log.Debug().Msgf("Fresh products due to %v: %d", i, moreProducts)
-		}
-		log.Info().Msgf("There are %d different fresh products", freshCount)
-	} else {
-		staleProducts := findStaleProducts(intervals, products)
+type day5 struct {
+	fresh    intervals.Set
+	products []int
+}
 
-		log.Info().Msgf("There are %d fresh products", len(products)-len(staleProducts))
+func (d *day5) Parse(input io.Reader) error {
+	fresh, products, err := parseInput(input)
+	if err != nil {
+		return err
 	}
+	d.fresh, d.products = fresh, products
+	return nil
 }
 
-func parseInput(inputFilename string) (mapset.Set[*Interval], []int) {
-	file, err := os.Open(inputFilename)
+func (d *day5) Part1(ctx context.Context) (Answer, error) {
+	staleProducts, err := findStaleProducts(ctx, d.fresh, d.products)
 	if err != nil {
-		log.Fatal().Err(err).Send()
+		return Answer{}, err
 	}
-	defer file.Close()
-
-	intervals := mapset.NewSet[*Interval]()
-	products := []int{}
-
-	scanner := bufio.NewScanner(file)
-	readingProducts := false
-	merged := 0
+	log.Debug().Msgf("There are %d fresh products", len(d.products)-len(staleProducts))
+	return IntAnswer(len(d.products) - len(staleProducts)), nil
+}
 
-	for scanner.Scan() {
-		if readingProducts {
-			product, _ := strconv.Atoi(scanner.Text())
-			products = append(products, product)
-			log.Trace().Msgf("Registered product %d", product)
-			continue
-		}
-		if scanner.Text() == "" {
-			readingProducts = true
-			continue
-		}
-		newInterval := ParseInterval(scanner.Text())
-		log.Trace().Msgf("Registered new interval %v", newInterval)
-		intervals.Add(&newInterval)
+func (d *day5) Part2(ctx context.Context) (Answer, error) {
+	freshCount := newCounter(0)
+	for _, i := range d.fresh.Intervals() {
+		log.Debug().Msgf("Fresh products due to %v: %v", i, intervalLen(i))
+		freshCount = freshCount.add(intervalLen(i))
 	}
-
-	intervals, newMerged := compactIntervals(intervals)
-	merged += newMerged
-
-	log.Debug().Msgf("Registered %d products, and %d intervals of fresh products (merged into %v)", len(products), intervals.Cardinality()+merged, intervals.Cardinality())
-
-	return intervals, products
+	log.Debug().Msgf("There are %v different fresh products", freshCount)
+	return freshCount.answer(), nil
 }
 
-func compactIntervals(intervals mapset.Set[*Interval]) (mapset.Set[*Interval], int) {
-	items := intervals.ToSlice()
+// intervalLen counts the ids of an interval, which may be more than an int holds
+func intervalLen(i intervals.Interval) counter {
+	// Upper-Lower+1 as a sum of terms that can't overflow on their own
+	return newCounter(i.Upper).add(newCounter(-1 - i.Lower)).add(newCounter(2))
+}
 
-	if len(items) <= 1 {
-		return intervals, 0
+// parseInput reads the ranges of fresh ids, then the available products after a blank line
+func parseInput(input io.Reader) (intervals.Set, []int, error) {
+	lines, err := parse.Lines(input)
+	if err != nil {
+		return intervals.Set{}, nil, err
 	}
+	sections := parse.Blocks(lines)
+	if len(sections) > 2 {
+		extra := sections[2][0]
+		return intervals.Set{}, nil, fromParseError(extra.Fail(errors.New("unexpected section after the products")))
+	}
+	sections = append(sections, nil, nil)
 
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Lower < items[j].Lower
-	})
-
-	mergedCount := 0
-	result := mapset.NewSet[*Interval]()
-
-	current := items[0]
-	result.Add(current)
-
-	for i := 1; i < len(items); i++ {
-		next := items[i]
-
-		if current.Merge(*next) {
-			mergedCount++
-		} else {
-			current = next
-			result.Add(current)
+	ranges, err := parse.Each(sections[0], parse.Range)
+	if err != nil {
+		return intervals.Set{}, nil, fromParseError(err)
+	}
+	fresh := intervals.NewSet(ranges...)
+	products, err := parse.Each(sections[1], func(s parse.Span) (int, error) {
+		product, err := parse.Int(s)
+		if err != nil {
+			return 0, s.Fail(errors.New("invalid product id"))
 		}
+		return product, nil
+	})
+	if err != nil {
+		return intervals.Set{}, nil, fromParseError(err)
 	}
 
-	return result, mergedCount
+	log.Debug().Msgf("Registered %d products, and %d intervals of fresh products (merged into %v)", len(products), len(ranges), len(fresh.Intervals()))
+
+	return fresh, products, nil
 }
 
-func findStaleProducts(intervals mapset.Set[*Interval], products []int) []int {
+func findStaleProducts(ctx context.Context, fresh intervals.Set, products []int) ([]int, error) {
 	staleProducts := []int{}
-out:
-	for _, p := range products {
-		for _, i := range intervals.ToSlice() {
-			if i.Contains(p) {
-				log.Trace().Msgf("Product %d\tis fresh (in interval %v)", p, i)
-				continue out
-			}
+	for checked, p := range products {
+		if ctx.Err() != nil {
+			return nil, cancelled(ctx, "checking %d of %d products", checked, len(products))
+		}
+		if i, found := fresh.Find(p); found {
+			log.Trace().Msgf("Product %d\tis fresh (in interval %v)", p, i)
+			continue
 		}
 		staleProducts = append(staleProducts, p)
 	}
-	return staleProducts
-}
-
-type Interval struct {
-	Lower int
-	Upper int
+	return staleProducts, nil
 }
 
-func ParseInterval(input string) Interval {
-	bounds := strings.Split(input, "-")
-	if len(bounds) != 2 {
-		log.Fatal().Msgf("Invalid interval format: %s", input)
-	}
-	lower, _ := strconv.Atoi(bounds[0])
-	upper, _ := strconv.Atoi(bounds[1])
-	return Interval{
-		Lower: lower,
-		Upper: upper,
+func (d *day5) ExploreCommands() []string {
+	return []string{
+		"show               list the compacted intervals of fresh ids",
+		"query <id>         tell whether an id is fresh, and which interval holds it",
 	}
 }
 
-func (i Interval) Contains(target int) bool {
-	return target >= i.Lower && target <= i.Upper
-}
-
-func (i Interval) String() string {
-	return fmt.Sprintf("(%d, %d)", i.Lower, i.Upper)
+func (d *day5) Explore(ctx context.Context, w io.Writer, command string, args []string) error {
+	switch command {
+	case "show":
+		fresh := d.fresh.Intervals()
+		for _, i := range fresh {
+			fmt.Fprintf(w, "%v %v ids\n", i, intervalLen(i))
+		}
+		fmt.Fprintf(w, "%d intervals, %d products\n", len(fresh), len(d.products))
+	case "query":
+		if len(args) != 1 {
+			return errors.New("usage: query <id>")
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid id %q", args[0])
+		}
+		if i, found := d.fresh.Find(id); found {
+			fmt.Fprintf(w, "%d is fresh, in %v\n", id, i)
+		} else {
+			fmt.Fprintf(w, "%d is spoiled\n", id)
+		}
+	default:
+		return errUnknownCommand
+	}
+	return nil
 }
 
-func (i *Interval) Merge(other Interval) bool {
-	extended := false
-	if other.Upper > i.Upper && other.Lower <= i.Upper+1 {
-		// other Interval _extends_ the current to the right
-		// 3-5 merge 4-6 = 3-6
-		// 3-5 merge 5-6 = 3-6
-		// 3-5 merge 1-6 = 3-6 <- this branch will cover only the upper part
-		log.Trace().Msgf("Extending %v to the right:\n\tinput %v\n\tnew interval: (%d, %d)", *i, other, i.Lower, other.Upper)
-		(*i).Upper = other.Upper
-		extended = true
+// Generate writes size intervals of fresh ids up to 10^15, which may overlap, then the products to check,
+// half of them picked within an interval
+func (d *day5) Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error {
+	products := 1000
+	if err := generatorParams(params, map[string]any{"products": &products}); err != nil {
+		return err
+	}
+	if size == 0 {
+		size = 175
 	}
-	if other.Lower < i.Lower && other.Upper >= i.Lower-1 {
-		// same as the upper branch, but for the lower bound
-		log.Trace().Msgf("Extending %v to the left:\n\tgiven %v\n\tnew interval: (%d, %d)", *i, other, other.Lower, i.Upper)
-		(*i).Lower = other.Lower
-		extended = true
+	const maxId = 1_000_000_000_000_000
+	fresh := make([]intervals.Interval, size)
+	for i := range fresh {
+		lower := 1 + rng.IntN(maxId)
+		fresh[i] = intervals.Interval{Lower: lower, Upper: lower + rng.IntN(maxId/100)}
+		fmt.Fprintf(w, "%d-%d\n", fresh[i].Lower, fresh[i].Upper)
 	}
-	if other.Lower >= i.Lower && other.Upper <= i.Upper {
-		// already included
-		return true
+	fmt.Fprintln(w)
+	for range products {
+		id := 1 + rng.IntN(maxId)
+		if rng.IntN(2) == 0 {
+			i := fresh[rng.IntN(size)]
+			n, _ := i.Len()
+			id = i.Lower + rng.IntN(n)
+		}
+		fmt.Fprintln(w, id)
 	}
-	return extended
+	return nil
 }
@@ -1,82 +1,98 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"math/rand/v2"
 	"strconv"
+	"strings"
 	"unicode"
 
+	"dmedinag/adventofcode2025/parse"
+
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/cobra"
-	"github.com/vallerion/rscanner"
 )
 
-var day6Cmd = &cobra.Command{
-	Use:   "day6",
-	Short: "cephalopod math",
-	Run:   runDay6,
+func init() {
+	Register(Day{
+		Number: 6,
+		Title:  "cephalopod math",
+		New:    func() Solver { return &day6{} },
+	})
 }
 
-func init() {
-	rootCmd.AddCommand(day6Cmd)
+type day6 struct {
+	operations []*Operation
 }
 
-func runDay6(cmd *cobra.Command, args []string) {
-	inputFilename, _ := cmd.Flags().GetString("input-file")
-	isFollowUp, _ := cmd.Flags().GetBool("follow-up")
-	file, err := os.Open(inputFilename)
+func (d *day6) Parse(input io.Reader) error {
+	lines, err := parse.Lines(input)
 	if err != nil {
-		log.Fatal().Err(err).Send()
+		return err
 	}
-	fs, err := file.Stat()
-
-	scanner := rscanner.NewScanner(file, fs.Size())
-
-	// get operators
-	for scanner.Scan() {
-		if scanner.Text() != "" {
-			break
-		}
+	// operators live on the last line, the operands on the ones above
+	lines = parse.TrimBlank(lines)
+	if len(lines) == 0 {
+		return &ParseError{Line: 1, Err: errors.New("no operators found")}
 	}
-
-	operations := parseOperations(scanner.Text())
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		parseOperands(operations, line, isFollowUp)
+	operations, err := parseOperations(lines[len(lines)-1])
+	if err != nil {
+		return fromParseError(err)
 	}
 
-	if scanner.Err() != nil {
-		log.Fatal().Err(err).Send()
+	for _, line := range lines[:len(lines)-1] {
+		if err := parseOperands(operations, line); err != nil {
+			return fromParseError(err)
+		}
 	}
+	d.operations = operations
+	return nil
+}
 
-	// aggregate operations
-	result := 0
-	for _, op := range operations {
-		partialResult := op.Result
-		if isFollowUp {
-			partialResult = op.GetVerticalResult()
+func (d *day6) Part1(ctx context.Context) (Answer, error) {
+	result := newCounter(0)
+	for i, op := range d.operations {
+		if ctx.Err() != nil {
+			return Answer{}, cancelled(ctx, "solving %d of %d problems", i, len(d.operations))
 		}
-		result += partialResult
+		result = result.add(op.Result)
 	}
+	log.Debug().Msgf("The result of the cephalopod math is: %v", result)
+	return result.answer(), nil
+}
 
-	log.Info().Msgf("The result of the cephalopod math is: %d", result)
+func (d *day6) Part2(ctx context.Context) (Answer, error) {
+	result := newCounter(0)
+	for i, op := range d.operations {
+		if ctx.Err() != nil {
+			return Answer{}, cancelled(ctx, "solving %d of %d problems", i, len(d.operations))
+		}
+		partialResult, err := op.GetVerticalResult()
+		if err != nil {
+			return Answer{}, err
+		}
+		result = result.add(partialResult)
+	}
+	log.Debug().Msgf("The result of the cephalopod math is: %v", result)
+	return result.answer(), nil
 }
 
-func parseOperations(line string) []*Operation {
+func parseOperations(line parse.Span) ([]*Operation, error) {
 	operations := []*Operation{}
 	operandSize := 0
 	operator := Unknown
 
-	log.Trace().Msgf("Scanning operations from last line %q", line)
-	for _, o := range line {
-		if o == ' ' {
+	log.Trace().Msgf("Scanning operations from last line %q", line.Text)
+	for i, o := range line.Text {
+		if o == ' ' && i > 0 {
 			operandSize++
 			continue
 		}
 		newOperator, err := ParseOperator(o)
 		if err != nil {
-			log.Fatal().Err(err).Send()
+			return nil, line.FailAt(i, string(o), err)
 		}
 		if operandSize == 0 {
 			// special case for first operator
@@ -98,28 +114,42 @@ func parseOperations(line string) []*Operation {
 	log.Trace().Msgf("Created final operation: %v", operation)
 	operations = append(operations, operation)
 
-	return operations
+	return operations, nil
 }
 
-func parseOperands(operations []*Operation, line string, isFollowUp bool) {
-	log.Trace().Msgf("Scanning operands from line %v", line)
-	cursor := 0
-	for _, op := range operations {
-		numStr := line[cursor : cursor+op.operandSize]
-		cursor += op.operandSize + 1
+func parseOperands(operations []*Operation, line parse.Span) error {
+	log.Trace().Msgf("Scanning operands from line %v", line.Text)
+	widths := make([]int, len(operations))
+	for i, op := range operations {
+		widths[i] = op.operandSize
+	}
+	// operands are separated by a single blank column
+	fields, err := parse.Columns(line, widths, 1)
+	if err != nil {
+		return err
+	}
+
+	for i, op := range operations {
+		numStr := fields[i].Text
 		log.Trace().Msgf("Parsed operand: %q for operation %v", numStr, op)
-		if isFollowUp {
-			op.OperateVertical(numStr)
-		} else {
-			num, _ := strconv.Atoi(numStr)
-			op.Operate(num)
+		for j, r := range numStr {
+			if r != ' ' && !unicode.IsDigit(r) {
+				return fields[i].FailAt(j, string(r), errors.New("invalid operand digit"))
+			}
+		}
+		num, err := strconv.Atoi(strings.TrimSpace(numStr))
+		if err != nil {
+			return fields[i].Fail(errors.New("invalid operand"))
 		}
+		op.OperateVertical(numStr)
+		op.Operate(num)
 	}
+	return nil
 }
 
 type Operation struct {
 	Operator    Operator
-	Result      int
+	Result      counter
 	operandSize int
 	cache       [][]rune
 	initialized bool
@@ -134,7 +164,7 @@ const (
 )
 
 func (o *Operation) Operate(operand int) {
-	o.Result = o.Operator.Apply(o.Result, operand)
+	o.Result = o.Operator.Apply(o.Result, newCounter(operand))
 	log.Trace().Msgf("New value: %v", o.Result)
 }
 
@@ -147,27 +177,28 @@ func (o *Operation) OperateVertical(operand string) {
 	o.cache = append(o.cache, parsedOperand)
 }
 
-func (o *Operation) GetVerticalResult() int {
+func (o *Operation) GetVerticalResult() (counter, error) {
 	operands := []int{}
 	// operandsFromCache
 	for i := range o.operandSize {
 		operand := ""
 		for _, row := range o.cache {
 			if unicode.IsNumber(row[i]) {
-				operand = string(row[i]) + operand
+				operand += string(row[i])
 			}
 		}
 		operandInt, err := strconv.Atoi(operand)
 		if err != nil {
-			log.Panic().Err(err).Msgf("failed to parse operand %q", operand)
+			return counter{}, fmt.Errorf("%v: no operand in column %d", o, i+1)
 		}
 		log.Debug().Str("operation", o.String()).Msgf("Found operand %d", operandInt)
 		operands = append(operands, operandInt)
 	}
+	result := newCounter(o.Operator.identity())
 	for _, operand := range operands {
-		o.Result = o.Operator.Apply(o.Result, operand)
+		result = o.Operator.Apply(result, newCounter(operand))
 	}
-	return o.Result
+	return result, nil
 }
 
 func (o Operation) String() string {
@@ -178,9 +209,7 @@ func (o *Operation) Init() {
 	if o.initialized {
 		return
 	}
-	if o.Operator == Multiply {
-		o.Result = 1
-	}
+	o.Result = newCounter(o.Operator.identity())
 	o.initialized = true
 }
 
@@ -191,7 +220,7 @@ func ParseOperator(t rune) (Operator, error) {
 	case '*':
 		return Multiply, nil
 	}
-	return Unknown, fmt.Errorf("unknown operator: %v", t)
+	return Unknown, fmt.Errorf("unknown operator %q", t)
 }
 
 func (o Operator) String() string {
@@ -205,13 +234,69 @@ func (o Operator) String() string {
 	}
 }
 
-func (o Operator) Apply(a, b int) int {
+// identity is the neutral element of the operator, i.e. the result of applying it to no operands
+func (o Operator) identity() int {
+	if o == Multiply {
+		return 1
+	}
+	return 0
+}
+
+func (o Operator) Apply(a, b counter) counter {
 	switch o {
 	case Multiply:
-		return a * b
+		return a.mul(b)
 	case Sum:
-		return a + b
+		return a.add(b)
+	}
+	panic(fmt.Sprintf("unknown operator: %v", o))
+}
+
+// Generate writes a worksheet of size problems with rows operands each, of up to 4 digits aligned to
+// either side of their problem
+func (d *day6) Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error {
+	rows := 4
+	if err := generatorParams(params, map[string]any{"rows": &rows}); err != nil {
+		return err
+	}
+	if size == 0 {
+		size = 1000
+	}
+	lines := make([]strings.Builder, rows+1)
+	for problem := range size {
+		if problem > 0 {
+			for i := range lines {
+				lines[i].WriteByte(' ')
+			}
+		}
+		width := 1 + rng.IntN(4)
+		rightAligned := rng.IntN(2) == 0
+		// one operand spans the whole problem, so that every column holds a digit for part 2
+		widest := rng.IntN(rows)
+		for row := range rows {
+			digits := width
+			if row != widest {
+				digits = 1 + rng.IntN(width)
+			}
+			operand := make([]byte, digits)
+			for i := range operand {
+				operand[i] = byte('1' + rng.IntN(9))
+			}
+			padding := strings.Repeat(" ", width-digits)
+			if rightAligned {
+				lines[row].WriteString(padding + string(operand))
+			} else {
+				lines[row].WriteString(string(operand) + padding)
+			}
+		}
+		operator := Sum
+		if rng.IntN(2) == 0 {
+			operator = Multiply
+		}
+		lines[rows].WriteString(operator.String() + strings.Repeat(" ", width-1))
+	}
+	for i := range lines {
+		fmt.Fprintln(w, lines[i].String())
 	}
-	log.Fatal().Msgf("unknown operator: %v", o)
-	panic("")
+	return nil
 }
@@ -1,54 +1,78 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"errors"
-	"os"
-	"sync"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
 	"sync/atomic"
 
+	"dmedinag/adventofcode2025/grid"
+	"dmedinag/adventofcode2025/parse"
+
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/rs/zerolog/log"
-	"github.com/spf13/cobra"
 )
 
-// day7Cmd represents the day7 command
-var day7Cmd = &cobra.Command{
-	Use:   "day7",
-	Short: "Tachyon beams split",
-	Run:   runDay7,
+func init() {
+	Register(Day{
+		Number: 7,
+		Title:  "Tachyon beams split",
+		New:    func() Solver { return &day7{} },
+	})
 }
 
-func init() {
-	rootCmd.AddCommand(day7Cmd)
+type day7 struct {
+	startCol int
+	// splits holds the splitters of the manifold, its bounds being the manifold's
+	splits *grid.Sparse[bool]
+	// explorer is set up by the first repl command
+	explorer *beamExplorer
 }
 
-func runDay7(cmd *cobra.Command, args []string) {
-	inputFilename, _ := cmd.Flags().GetString("input-file")
-	isFollowUp, _ := cmd.Flags().GetBool("follow-up")
+func (d *day7) Parse(input io.Reader) error {
+	startCol, splits, err := parseTachyonInput(input)
+	if err != nil {
+		return err
+	}
+	log.Trace().Msgf("Start column: %d, row count: %d", startCol, splits.Rows())
+	log.Trace().Msgf("All splits %v", splits)
+	d.startCol, d.splits = startCol, splits
+	return nil
+}
 
-	startCol, rowCount, splits := parseTachyonInput(inputFilename)
-	log.Trace().Msgf("Start column: %d, row count: %d", startCol, rowCount)
-	log.Trace().Msgf("All splits %v", &splits)
+func (d *day7) Part1(ctx context.Context) (Answer, error) {
+	result, err := traceRays(ctx, d.splits, d.startCol)
+	if err != nil {
+		return Answer{}, err
+	}
+	log.Debug().Msgf("Split %d times", result)
+	return IntAnswer(result), nil
+}
 
-	if isFollowUp {
-		result := countPaths(splits, startCol, rowCount)
-		log.Info().Msgf("There are %d possible paths for the particle", result)
-	} else {
-		result := traceRays(splits, startCol, rowCount)
-		log.Info().Msgf("Split %d times", result)
+func (d *day7) Part2(ctx context.Context) (Answer, error) {
+	result, err := countPaths(ctx, d.splits, d.startCol)
+	if err != nil {
+		return Answer{}, err
 	}
+	log.Debug().Msgf("There are %v possible paths for the particle", result)
+	return result.answer(), nil
 }
 
-func traceRays(splits *sync.Map, startCol, rowCount int) int {
+func traceRays(ctx context.Context, splits grid.Grid[bool], startCol int) (int, error) {
+	rowCount := splits.Rows()
 	rays := mapset.NewSet[int]()
 	rays.Add(startCol)
 	var splitCount atomic.Int32
 	for row := range rowCount {
+		if ctx.Err() != nil {
+			return 0, cancelled(ctx, "tracing %d of %d rows, %d splits so far", row, rowCount, splitCount.Load())
+		}
 		nextRays := mapset.NewSet[int]()
 		for ray := range rays.Iterator().C {
-			targetPosition := position{row: row, col: ray}
-			_, exists := splits.Load(targetPosition)
+			_, exists := splits.Get(grid.Point{Row: row, Col: ray})
 			if exists {
 				log.Debug().Msgf("Ray split on row %d at col %d", row, ray)
 				splitCount.Add(1)
@@ -61,22 +85,26 @@ func traceRays(splits *sync.Map, startCol, rowCount int) int {
 		}
 		rays = nextRays
 	}
-	return int(splitCount.Load())
+	return int(splitCount.Load()), nil
 }
 
-func countPaths(splits *sync.Map, startCol, rowCount int) int {
+func countPaths(ctx context.Context, splits grid.Grid[bool], startCol int) (counter, error) {
+	rowCount := splits.Rows()
 	paths := mapset.NewSet[*path]()
-	paths.Add(&path{ray: startCol, origins: 1})
+	paths.Add(&path{ray: startCol, origins: newCounter(1)})
 	for row := range rowCount {
-		nextRays := map[int]int{}
+		if ctx.Err() != nil {
+			return counter{}, cancelled(ctx, "following paths through %d of %d rows", row, rowCount)
+		}
+		// the number of paths doubles at every splitter, it may only fit in a big.Int
+		nextRays := map[int]counter{}
 		for p := range paths.Iterator().C {
-			targetPosition := position{row: row, col: p.ray}
-			_, exists := splits.Load(targetPosition)
+			_, exists := splits.Get(grid.Point{Row: row, Col: p.ray})
 			if exists {
-				addOrIncrease(nextRays, p.ray-1, p.origins)
-				addOrIncrease(nextRays, p.ray+1, p.origins)
+				nextRays[p.ray-1] = nextRays[p.ray-1].add(p.origins)
+				nextRays[p.ray+1] = nextRays[p.ray+1].add(p.origins)
 			} else {
-				addOrIncrease(nextRays, p.ray, p.origins)
+				nextRays[p.ray] = nextRays[p.ray].add(p.origins)
 			}
 		}
 		paths = mapset.NewSet[*path]()
@@ -84,76 +112,220 @@ func countPaths(splits *sync.Map, startCol, rowCount int) int {
 			paths.Add(&path{ray: k, origins: v})
 		}
 	}
-	splitCount := 0
+	splitCount := newCounter(0)
 	for p := range paths.Iterator().C {
-		splitCount += p.origins
-	}
-	return splitCount
-}
-
-func addOrIncrease(m map[int]int, key, delta int) {
-	if prev, exists := m[key]; !exists {
-		m[key] = delta
-	} else {
-		m[key] = prev + delta
+		splitCount = splitCount.add(p.origins)
 	}
+	return splitCount, nil
 }
 
 type path struct {
 	ray     int
-	origins int
+	origins counter
 }
 
-func parseTachyonInput(inputFilename string) (startCol int, rowCount int, splits *sync.Map) {
-	file, err := os.Open(inputFilename)
+func parseTachyonInput(input io.Reader) (startCol int, splits *grid.Sparse[bool], err error) {
+	lines, err := parse.Lines(input)
 	if err != nil {
-		log.Fatal().Err(err).Send()
+		return 0, nil, err
 	}
-	scanner := bufio.NewScanner(file)
-	splits = &sync.Map{}
+	startCol = -1
+	splits, err = parse.SparseGrid(lines, func(p grid.Point, r rune) (bool, bool, error) {
+		switch {
+		case r == '.':
+			return false, false, nil
+		case r == 'S' && p.Row == 0:
+			if startCol >= 0 {
+				return false, false, errors.New("more than one starting position")
+			}
+			startCol = p.Col
+			return false, false, nil
+		case r == '^' && p.Row > 0:
+			log.Debug().Msgf("Split at row %d, col %d", p.Row, p.Col)
+			return true, true, nil
+		}
+		return false, false, errors.New("unknown manifold element")
+	})
+	if err != nil {
+		return 0, nil, fromParseError(err)
+	}
+	if startCol < 0 {
+		text := ""
+		if len(lines) > 0 {
+			text = lines[0].Text
+		}
+		return 0, nil, &ParseError{Line: 1, Text: text, Err: errors.New("starting position not found")}
+	}
+	return startCol, splits, nil
+}
 
-	for scanner.Scan() {
-		if rowCount == 0 {
-			startCol, _ = findStart(scanner.Text())
-		} else {
-			lineSplits, err := parseSplits(scanner.Text())
-			if err != nil {
-				continue
+// beamExplorer is the repl state of day 7, following the beams one row at a time
+type beamExplorer struct {
+	// beams holds, for every traced row, the timelines entering and leaving it by column
+	beams  [][2]map[int]counter
+	splits int
+}
+
+// timelines returns the timelines leaving the last traced row by column, the start before any row is traced
+func (d *day7) timelines() map[int]counter {
+	if traced := len(d.explorer.beams); traced > 0 {
+		return d.explorer.beams[traced-1][1]
+	}
+	return map[int]counter{d.startCol: newCounter(1)}
+}
+
+func (d *day7) ExploreCommands() []string {
+	return []string{
+		"show               draw the manifold, with the beams traced so far",
+		"step [n]           trace the beams through the next n rows (default 1)",
+	}
+}
+
+func (d *day7) Explore(ctx context.Context, w io.Writer, command string, args []string) error {
+	if d.explorer == nil {
+		d.explorer = &beamExplorer{}
+	}
+	e := d.explorer
+
+	switch command {
+	case "show":
+		grid.Render(w, d.splits, func(p grid.Point, _ bool, split bool) rune {
+			switch {
+			case split:
+				return '^'
+			case p.Row == 0 && p.Col == d.startCol:
+				return 'S'
+			case p.Row < len(e.beams):
+				if _, beam := e.beams[p.Row][0][p.Col]; beam {
+					return '|'
+				}
+			}
+			return '.'
+		})
+		fmt.Fprintf(w, "%d of %d rows traced, %d splits, %s\n", len(e.beams), d.splits.Rows(), e.splits, describeTimelines(countTimelines(d.timelines())))
+	case "step":
+		rows, err := replCount(args)
+		if err != nil {
+			return err
+		}
+		for range rows {
+			row := len(e.beams)
+			if row >= d.splits.Rows() {
+				fmt.Fprintln(w, "every row has been traced")
+				break
 			}
-			for _, pos := range lineSplits {
-				log.Debug().Msgf("Split at row %d, col %d", rowCount, pos)
-				splits.Store(position{row: rowCount, col: pos}, false)
+			if ctx.Err() != nil {
+				return cancelled(ctx, "tracing %d of %d rows", row, d.splits.Rows())
 			}
+			entering := d.timelines()
+			leaving, rowSplits := traceRow(d.splits, row, entering)
+			e.beams = append(e.beams, [2]map[int]counter{entering, leaving})
+			e.splits += rowSplits
+			fmt.Fprintf(w, "row %d: %d splits, %d beams and %s leaving it\n", row, rowSplits, len(leaving), describeTimelines(countTimelines(leaving)))
 		}
-		rowCount++
+	default:
+		return errUnknownCommand
 	}
-
-	return
+	return nil
 }
 
-func findStart(line string) (int, error) {
-	for i, r := range line {
-		if r == 'S' {
-			return i, nil
+// traceRow follows the timelines entering a row by column, returning the ones leaving it and the splits they hit
+func traceRow(splits grid.Grid[bool], row int, entering map[int]counter) (leaving map[int]counter, rowSplits int) {
+	leaving = map[int]counter{}
+	for col, origins := range entering {
+		if _, split := splits.Get(grid.Point{Row: row, Col: col}); split {
+			rowSplits++
+			leaving[col-1] = leaving[col-1].add(origins)
+			leaving[col+1] = leaving[col+1].add(origins)
+		} else {
+			leaving[col] = leaving[col].add(origins)
 		}
 	}
-	return 0, errors.New("starting position not found")
+	return leaving, rowSplits
 }
 
-func parseSplits(line string) ([]int, error) {
-	positions := []int{}
-	for i, r := range line {
-		if r == '^' {
-			positions = append(positions, i)
+// Render draws the manifold as SVG, with the width of the beams growing with the timelines they carry
+func (d *day7) Render(ctx context.Context, w io.Writer) error {
+	const cell = 6
+	rowCount, colCount := d.splits.Rows(), d.splits.Cols()
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, colCount*cell, rowCount*cell, colCount*cell, rowCount*cell)
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="#10141c"/>`)
+
+	// the beams are traced first, to scale their width with the largest timeline count
+	rows := make([]map[int]counter, rowCount)
+	entering := map[int]counter{d.startCol: newCounter(1)}
+	maxTimelines := 1.0
+	for row := range rowCount {
+		if ctx.Err() != nil {
+			return cancelled(ctx, "tracing %d of %d rows", row, rowCount)
+		}
+		rows[row] = entering
+		for _, origins := range entering {
+			maxTimelines = max(maxTimelines, origins.float())
 		}
+		entering, _ = traceRow(d.splits, row, entering)
 	}
-	if len(positions) == 0 {
-		return nil, errors.New("no splits found in line")
+
+	for row, beams := range rows {
+		for col, origins := range beams {
+			if _, split := d.splits.Get(grid.Point{Row: row, Col: col}); split || col < 0 || col >= colCount {
+				continue
+			}
+			// a count that wrapped may even be negative, it is drawn as thin as a single timeline
+			width := 1 + 3*math.Log(max(origins.float(), 1))/math.Log(maxTimelines+1)
+			x := col*cell + cell/2
+			fmt.Fprintf(w, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#ffd34d" stroke-width="%.2f"><title>%s</title></line>`, x, row*cell, x, (row+1)*cell, width, describeTimelines(origins))
+		}
+	}
+	for p := range d.splits.All() {
+		x, y := p.Col*cell, p.Row*cell
+		fmt.Fprintf(w, `<polygon points="%d,%d %d,%d %d,%d" fill="#7aa2f7"/>`, x+cell/2, y, x, y+cell, x+cell, y+cell)
+	}
+	fmt.Fprintf(w, `<circle cx="%d" cy="%d" r="%d" fill="#f7768e"/>`, d.startCol*cell+cell/2, cell/2, cell/2)
+	fmt.Fprint(w, `</svg>`)
+	return nil
+}
+
+func countTimelines(beams map[int]counter) counter {
+	timelines := newCounter(0)
+	for _, origins := range beams {
+		timelines = timelines.add(origins)
+	}
+	return timelines
+}
+
+// describeTimelines prints a count of timelines, flagging it when it overflowed int as Solve does
+func describeTimelines(timelines counter) string {
+	if timelines.answer().Wrapped() {
+		return fmt.Sprintf("%v timelines (overflowed int, explore with --bigint for the exact count)", timelines)
 	}
-	return positions, nil
+	return fmt.Sprintf("%v timelines", timelines)
 }
 
-type position struct {
-	row int
-	col int
+// Generate writes a manifold with size rows of splitters, every other row below the start. Each position
+// a beam may reach holds a splitter with probability density.
+func (d *day7) Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error {
+	density := 0.7
+	if err := generatorParams(params, map[string]any{"density": &density}); err != nil {
+		return err
+	}
+	if size == 0 {
+		size = 70
+	}
+	// the beams spread a column to each side at every row of splitters, they never leave the manifold
+	manifold := grid.NewSparse[rune](2*size+2, 2*size+1)
+	manifold.Set(grid.Point{Row: 0, Col: size}, 'S')
+	for k := 1; k <= size; k++ {
+		for col := size - k + 1; col < size+k; col += 2 {
+			if rng.Float64() < density {
+				manifold.Set(grid.Point{Row: 2 * k, Col: col}, '^')
+			}
+		}
+	}
+	return grid.Render(w, manifold, func(_ grid.Point, element rune, set bool) rune {
+		if set {
+			return element
+		}
+		return '.'
+	})
 }
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"dmedinag/adventofcode2025/parse"
+)
+
+// ParseError reports malformed puzzle input, pointing at the offending text
+type ParseError struct {
+	File   string
+	Line   int // 1-based, 0 if unknown
+	Column int // 1-based, 0 if unknown
+	Text   string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	location := e.File
+	if location == "" {
+		location = "<input>"
+	}
+	if e.Line > 0 {
+		location += fmt.Sprintf(":%d", e.Line)
+		if e.Column > 0 {
+			location += fmt.Sprintf(":%d", e.Column)
+		}
+	}
+	return fmt.Sprintf("%s: %v: %q", location, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// fromParseError turns the errors of the parse readers into a *ParseError, leaving any other error as is
+func fromParseError(err error) error {
+	if located := (*parse.Error)(nil); errors.As(err, &located) {
+		return &ParseError{Line: located.Line, Column: located.Column, Text: located.Text, Err: located.Err}
+	}
+	return err
+}
+
+// errNotImplemented is returned by the parts of a scaffolded day until they are solved
+var errNotImplemented = errors.New("not implemented")
+
+// CancelledError reports a part interrupted by the cancellation of its context, along with how far it got
+type CancelledError struct {
+	Progress string
+	Err      error
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("%v after %s", e.Err, e.Progress)
+}
+
+func (e *CancelledError) Unwrap() error {
+	return e.Err
+}
+
+// cancelled returns the error of a cancelled context, describing the progress made until then
+func cancelled(ctx context.Context, format string, args ...any) *CancelledError {
+	return &CancelledError{Progress: fmt.Sprintf(format, args...), Err: ctx.Err()}
+}
+
+// exitError makes a command exit with the given code rather than exitFailure
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// exitCodeOf returns the code a command failing with err exits with
+func exitCodeOf(err error) int {
+	if exitErr := (*exitError)(nil); errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return exitFailure
+}
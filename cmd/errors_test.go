@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		day          int
+		input        string
+		line, column int
+		text         string
+	}{
+		{1, "L68\nX30\n", 2, 1, "X"},
+		{1, "L68\nLx\n", 2, 2, "x"},
+		{2, "11-22,95-x\n", 1, 10, "x"},
+		{2, "11-22,9515\n", 1, 7, "9515"},
+		{3, "987\n12a4\n", 2, 3, "a"},
+		{4, "..@\n.#@\n", 2, 2, "#"},
+		{4, "..@\n.@\n", 2, 0, ".@"},
+		{5, "3-5\n10-x\n\n1\n", 2, 4, "x"},
+		{5, "3-5\n\nab\n", 3, 1, "ab"},
+		{6, "12 3\n4  5\n* -\n", 3, 3, "-"},
+		{6, "12 3\n4a 5\n*  +\n", 2, 2, "a"},
+		{7, "..S..\n..#..\n", 2, 3, "#"},
+		{7, ".....\n..^..\n", 1, 0, "....."},
+	} {
+		d, _ := LookupDay(tc.day)
+		file := filepath.Join(dir, "input")
+		if err := os.WriteFile(file, []byte(tc.input), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := d.SolveFile(context.Background(), file, 1, 2)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("day %d %q: got %v, want a *ParseError", tc.day, tc.input, err)
+			continue
+		}
+		if parseErr.File != file || parseErr.Line != tc.line || parseErr.Column != tc.column || parseErr.Text != tc.text {
+			t.Errorf("day %d %q: got %v, want it at %s:%d:%d on %q", tc.day, tc.input, err, file, tc.line, tc.column, tc.text)
+		}
+	}
+}
+
+func TestParseErrorOnStdin(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(file, []byte("L68\nX30\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	defer func(f *os.File) { os.Stdin = f }(os.Stdin)
+	os.Stdin = stdin
+
+	d, _ := LookupDay(1)
+	_, err = d.SolveFile(context.Background(), stdinInput, 1)
+	if want := `<stdin>:2:1: invalid rotation 'X': "X"`; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+}
+
+// countdownContext is cancelled once its Err method has been called a number of times
+type countdownContext struct {
+	context.Context
+	left atomic.Int64
+}
+
+func (c *countdownContext) Err() error {
+	if c.left.Add(-1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestCancelledProgress(t *testing.T) {
+	ctx := &countdownContext{Context: context.Background()}
+	ctx.left.Store(5)
+	d, _ := LookupDay(1)
+	solutions, err := d.SolveFile(ctx, filepath.Join("..", defaultInputsDir, "01_test"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cancelledErr *CancelledError
+	if !errors.As(solutions[0].Err, &cancelledErr) || !errors.Is(solutions[0].Err, context.Canceled) {
+		t.Fatalf("got %v, want a *CancelledError", solutions[0].Err)
+	}
+	// cancelled in the middle of the 10 rotations
+	var applied, total int
+	if _, err := fmt.Sscanf(cancelledErr.Progress, "applying %d of %d rotations", &applied, &total); err != nil || applied < 1 || applied >= total || total != 10 {
+		t.Errorf("got progress %q, want some of the 10 rotations applied", cancelledErr.Progress)
+	}
+}
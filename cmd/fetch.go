@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// fetchCmd represents the fetch command
+var fetchCmd = &cobra.Command{
+	Use:   "fetch <day>...",
+	Short: "Download puzzle inputs into the inputs directory, unless already there",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runFetch,
+}
+
+func init() {
+	rootCmd.AddCommand(fetchCmd)
+	addPuzzleSiteFlags(fetchCmd)
+}
+
+func runFetch(cmd *cobra.Command, args []string) error {
+	days := []int{}
+	for _, arg := range args {
+		day, err := strconv.Atoi(strings.TrimPrefix(arg, "day"))
+		if err != nil || day < 1 || day > 25 {
+			return fmt.Errorf("invalid day %q", arg)
+		}
+		days = append(days, day)
+	}
+
+	site, err := puzzleSiteFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		path, downloaded, err := site.Fetch(cmd.Context(), day)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+		if downloaded {
+			log.Info().Msgf("Downloaded day %d input to %s", day, path)
+		} else {
+			log.Info().Msgf("Day %d input already cached in %s", day, path)
+		}
+	}
+	return nil
+}
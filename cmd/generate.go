@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// Generator is implemented by solvers able to produce random valid inputs, to stress test them
+type Generator interface {
+	// Generate writes an input of the given size, the size of an actual input when 0.
+	// params tune the shape of the input, unknown ones are an error.
+	Generate(w io.Writer, rng *rand.Rand, size int, params map[string]string) error
+}
+
+// generateCmd represents the generate command
+var generateCmd = &cobra.Command{
+	Use:   "generate <day>",
+	Short: "Write a random valid input for a day, to stress test it",
+	Long: `Write a random valid input for a day on the standard output, to stress test it.
+
+The size is the number of rotations for day 1, of id ranges for day 2, of battery banks for day 3,
+the side of the grid for day 4, the number of intervals for day 5, of problems for day 6 and of
+splitter rows for day 7. Some days take parameters:
+
+  day 3  length=100       batteries per bank
+  day 4  density=0.6      fraction of the grid holding rolls
+  day 5  products=1000    products to check
+  day 6  rows=4           operand rows
+  day 7  density=0.7      fraction of the reachable positions holding splitters
+
+For example: adventofcode2025 generate 4 --size 500 --param density=0.3 | adventofcode2025 day4 -i -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.Flags().Uint64("seed", 0, "seed of the random generator, the current time if 0 (logged to reproduce the input)")
+	generateCmd.Flags().Int("size", 0, "size of the input, that of an actual input if 0")
+	generateCmd.Flags().StringToString("param", nil, "day specific parameter, as name=value")
+}
+
+func runGenerate(cmd *cobra.Command, args []string) error {
+	d, err := dayFromArg(args[0])
+	if err != nil {
+		return err
+	}
+	seed, _ := cmd.Flags().GetUint64("seed")
+	size, _ := cmd.Flags().GetInt("size")
+	params, _ := cmd.Flags().GetStringToString("param")
+	if size < 0 {
+		return fmt.Errorf("invalid size %d, expected a positive number", size)
+	}
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+
+	out := bufio.NewWriter(cmd.OutOrStdout())
+	if err := generateInput(out, d, seed, size, params); err != nil {
+		return err
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	log.Info().Msgf("Generated a day %d input with seed %d", d.Number, seed)
+	return nil
+}
+
+// generateInput writes a random input for a day, the same one for the same seed, size and params
+func generateInput(w io.Writer, d Day, seed uint64, size int, params map[string]string) error {
+	s, err := d.newSolver()
+	if err != nil {
+		return err
+	}
+	generator, ok := s.(Generator)
+	if !ok {
+		return fmt.Errorf("day %d can't generate inputs", d.Number)
+	}
+	return generator.Generate(w, rand.New(rand.NewPCG(seed, seed)), size, params)
+}
+
+// generatorParams reads the parameters of a generator into the *int or *float64 of their name,
+// which keep their value when not given
+func generatorParams(params map[string]string, values map[string]any) error {
+	for name, value := range params {
+		switch v := values[name].(type) {
+		case *int:
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid %s %q, expected a positive number", name, value)
+			}
+			*v = n
+		case *float64:
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil || f < 0 || f > 1 {
+				return fmt.Errorf("invalid %s %q, expected a number between 0 and 1", name, value)
+			}
+			*v = f
+		default:
+			return fmt.Errorf("unknown parameter %q", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGeneratedInputsSolve(t *testing.T) {
+	for _, d := range Days() {
+		t.Run(fmt.Sprintf("day%d", d.Number), func(t *testing.T) {
+			// days scaffolded with the new command don't generate inputs until they are written
+			if _, ok := d.New().(Generator); !ok {
+				t.Skipf("day %d can't generate inputs", d.Number)
+			}
+			var input bytes.Buffer
+			if err := generateInput(&input, d, 1, 20, nil); err != nil {
+				t.Fatal(err)
+			}
+			solutions, err := d.Solve(context.Background(), bytes.NewReader(input.Bytes()), 1, 2)
+			if err == nil {
+				err = errors.Join(solutions[0].Err, solutions[1].Err)
+			}
+			if err != nil {
+				t.Errorf("the generated input doesn't solve: %v\n%s", err, input.String())
+			}
+			var again bytes.Buffer
+			generateInput(&again, d, 1, 20, nil)
+			if !bytes.Equal(input.Bytes(), again.Bytes()) {
+				t.Error("the same seed generated different inputs")
+			}
+		})
+	}
+}
+
+func TestGeneratedLongLines(t *testing.T) {
+	// lines well beyond the 64 KiB bufio.Scanner reads by default
+	for _, tc := range []struct {
+		day    int
+		size   int
+		params map[string]string
+	}{
+		{2, 5000, nil},
+		{3, 2, map[string]string{"length": "100000"}},
+		{6, 20000, nil},
+	} {
+		d, _ := LookupDay(tc.day)
+		var input bytes.Buffer
+		if err := generateInput(&input, d, 1, tc.size, tc.params); err != nil {
+			t.Fatalf("day %d: %v", tc.day, err)
+		}
+		solutions, err := d.Solve(context.Background(), &input, 1, 2)
+		if err == nil {
+			err = errors.Join(solutions[0].Err, solutions[1].Err)
+		}
+		if err != nil {
+			t.Errorf("day %d: the generated input doesn't solve: %v", tc.day, err)
+		}
+	}
+}
+
+func TestGeneratorParams(t *testing.T) {
+	d, _ := LookupDay(4)
+	var empty, full bytes.Buffer
+	generateInput(&empty, d, 1, 5, map[string]string{"density": "0"})
+	generateInput(&full, d, 1, 5, map[string]string{"density": "1"})
+	if bytes.ContainsRune(empty.Bytes(), '@') || bytes.ContainsRune(full.Bytes(), '.') {
+		t.Errorf("got %q and %q, want grids without and full of rolls", empty.String(), full.String())
+	}
+	for _, params := range []map[string]string{{"width": "3"}, {"density": "2"}, {"density": "x"}} {
+		if err := generateInput(&bytes.Buffer{}, d, 1, 5, params); err == nil {
+			t.Errorf("%v: got no error, want an invalid parameter reported", params)
+		}
+	}
+
+	// part 2 activates 12 batteries per bank
+	d, _ = LookupDay(3)
+	if err := generateInput(&bytes.Buffer{}, d, 1, 5, map[string]string{"length": "11"}); err == nil {
+		t.Error("got no error, want banks too short for part 2 rejected")
+	}
+}
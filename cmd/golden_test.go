@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+var update = flag.Bool("update", false, "regenerate the expected results of the test inputs")
+
+func TestMain(m *testing.M) {
+	// solvers log every step at debug and trace level
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	os.Exit(m.Run())
+}
+
+// TestGolden solves every registered day on its test input and compares both parts with the expected
+// results stored next to it, in the same _test_result files used by the verify command.
+func TestGolden(t *testing.T) {
+	dir := filepath.Join("..", defaultInputsDir)
+
+	for _, d := range Days() {
+		t.Run(fmt.Sprintf("day%d", d.Number), func(t *testing.T) {
+			testInput, err := resolveInput(dir, d.Number, true)
+			if err != nil {
+				t.Skip(err)
+			}
+			resultFile := testInput + "_result"
+
+			expected, err := readExpectedAnswers(resultFile)
+			if errors.Is(err, fs.ErrNotExist) && !*update {
+				t.Skipf("no %s, run with -update to create it", resultFile)
+			}
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				t.Fatal(err)
+			}
+
+			// both parts are computed from a single parse, as they are on the command line
+			solutions, err := d.SolveFile(context.Background(), testInput, 1, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := [2]string{}
+			for i, solution := range solutions {
+				t.Run(fmt.Sprintf("part%d", solution.Part), func(t *testing.T) {
+					if expected[i] == "" && !*update {
+						t.Skipf("no expected answer in %s", resultFile)
+					}
+					if solution.Err != nil {
+						t.Fatal(solution.Err)
+					}
+					actual[i] = solution.Answer.String()
+					if !*update && actual[i] != expected[i] {
+						t.Errorf("got %s, want %s", actual[i], expected[i])
+					}
+				})
+			}
+
+			if *update {
+				content := strings.Join(actual[:], "\n") + "\n"
+				if err := os.WriteFile(resultFile, []byte(content), 0o664); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
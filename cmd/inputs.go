@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// inputsDirEnv names the environment variable overriding the default inputs directory
+const inputsDirEnv = "AOC_INPUTS_DIR"
+
+const defaultInputsDir = "inputs"
+
+// InputNotFoundError is returned when none of the candidate paths for a day's input exist
+type InputNotFoundError struct {
+	Day      int
+	Test     bool
+	Searched []string
+}
+
+func (e *InputNotFoundError) Error() string {
+	kind := "input"
+	if e.Test {
+		kind = "test input"
+	}
+	return fmt.Sprintf("no %s found for day %d, searched: %s", kind, e.Day, strings.Join(e.Searched, ", "))
+}
+
+// inputsDir returns the root holding the puzzle inputs.
+// The --inputs-dir flag takes precedence over $AOC_INPUTS_DIR, which takes precedence over the configuration files.
+func inputsDir(cmd *cobra.Command) string {
+	dir, _ := cmd.Flags().GetString("inputs-dir")
+	return dir
+}
+
+// inputCandidates lists the paths where a day's input may live, in search order
+func inputCandidates(dir string, day int, test bool) []string {
+	names := []string{fmt.Sprintf("%02d", day), strconv.Itoa(day)}
+	if day >= 10 {
+		names = names[:1]
+	}
+	candidates := []string{}
+	for _, name := range names {
+		if test {
+			name += "_test"
+		}
+		candidates = append(candidates, filepath.Join(dir, name))
+	}
+	return candidates
+}
+
+// resolveInput returns the first existing candidate for a day's input
+func resolveInput(dir string, day int, test bool) (string, error) {
+	candidates := inputCandidates(dir, day, test)
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", &InputNotFoundError{Day: day, Test: test, Searched: candidates}
+}
+
+// stdinInput is the input file name standing for the standard input
+const stdinInput = "-"
+
+// inputName returns how an input file is named in results and errors, <stdin> for "-"
+func inputName(filename string) string {
+	if filename == stdinInput {
+		return "<stdin>"
+	}
+	return filename
+}
+
+// openInput opens an input file, or the standard input for "-"
+func openInput(filename string) (io.ReadCloser, error) {
+	if filename == stdinInput {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(filename)
+}
+
+// selectInputFiles returns the inputs a day command should read: those passed with --input-file, or the day's resolved input
+func selectInputFiles(cmd *cobra.Command, day int) ([]string, error) {
+	inputFiles, _ := cmd.Flags().GetStringSlice("input-file")
+	if len(inputFiles) > 0 {
+		stdinCount := 0
+		for _, f := range inputFiles {
+			if f == stdinInput {
+				stdinCount++
+			}
+		}
+		if stdinCount > 1 {
+			return nil, errors.New("the standard input can only be read once")
+		}
+		return inputFiles, nil
+	}
+	test, _ := cmd.Flags().GetBool("test")
+	inputFile, err := resolveInput(inputsDir(cmd), day, test)
+	if err != nil {
+		return nil, err
+	}
+	return []string{inputFile}, nil
+}
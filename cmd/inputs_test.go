@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestInputCandidates(t *testing.T) {
+	for _, tc := range []struct {
+		day  int
+		test bool
+		want []string
+	}{
+		{3, false, []string{"in/03", "in/3"}},
+		{3, true, []string{"in/03_test", "in/3_test"}},
+		{12, false, []string{"in/12"}},
+	} {
+		if got := inputCandidates("in", tc.day, tc.test); !slices.Equal(got, tc.want) {
+			t.Errorf("day %d test %v: got %v, want %v", tc.day, tc.test, got, tc.want)
+		}
+	}
+}
+
+func TestResolveInput(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"3", "03_test", "3_test"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a directory is not an input
+	if err := os.Mkdir(filepath.Join(dir, "03"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for test, want := range map[bool]string{false: "3", true: "03_test"} {
+		if got, err := resolveInput(dir, 3, test); err != nil || got != filepath.Join(dir, want) {
+			t.Errorf("test %v: got (%s, %v), want %s", test, got, err, want)
+		}
+	}
+
+	_, err := resolveInput(dir, 4, true)
+	notFound := (*InputNotFoundError)(nil)
+	if !errors.As(err, &notFound) || !notFound.Test || !slices.Equal(notFound.Searched, inputCandidates(dir, 4, true)) {
+		t.Fatalf("got %v, want an *InputNotFoundError listing the candidates", err)
+	}
+	want := "no test input found for day 4, searched: " + filepath.Join(dir, "04_test") + ", " + filepath.Join(dir, "4_test")
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err, want)
+	}
+}
+
+func TestInputsDirPrecedence(t *testing.T) {
+	defer func() { configFiles = nil }()
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(config, []byte("inputs_dir: from-config\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		env, flag, want string
+	}{
+		{"", "", "from-config"},
+		{"from-env", "", "from-env"},
+		{"from-env", "from-flag", "from-flag"},
+	} {
+		t.Setenv(inputsDirEnv, tc.env)
+		cmd := &cobra.Command{}
+		cmd.Flags().String("config", config, "")
+		cmd.Flags().String("inputs-dir", defaultInputsDir, "")
+		if tc.flag != "" {
+			cmd.Flags().Set("inputs-dir", tc.flag)
+		}
+		if err := loadConfig(cmd); err != nil {
+			t.Fatal(err)
+		}
+		if got := inputsDir(cmd); got != tc.want {
+			t.Errorf("env %q, flag %q: got %s, want %s", tc.env, tc.flag, got, tc.want)
+		}
+	}
+}
+
+func TestSelectInputFiles(t *testing.T) {
+	newCmd := func(inputFiles ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("input-file", nil, "")
+		cmd.Flags().Bool("test", true, "")
+		cmd.Flags().String("inputs-dir", filepath.Join("..", defaultInputsDir), "")
+		for _, f := range inputFiles {
+			cmd.Flags().Set("input-file", f)
+		}
+		return cmd
+	}
+
+	if got, err := selectInputFiles(newCmd(), 1); err != nil || !slices.Equal(got, []string{filepath.Join("..", defaultInputsDir, "01_test")}) {
+		t.Errorf("got (%v, %v), want the test input of day 1", got, err)
+	}
+	if got, err := selectInputFiles(newCmd("a", "-", "b"), 1); err != nil || !slices.Equal(got, []string{"a", "-", "b"}) {
+		t.Errorf("got (%v, %v), want the given files in order", got, err)
+	}
+	if _, err := selectInputFiles(newCmd("-", "a", "-"), 1); err == nil {
+		t.Error("got no error, want the standard input rejected twice")
+	}
+}
+
+func TestResultsPerInput(t *testing.T) {
+	testInput := filepath.Join("..", defaultInputsDir, "01_test")
+	stdin, err := os.Open(testInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	defer func(f *os.File) { os.Stdin = f }(os.Stdin)
+	os.Stdin = stdin
+
+	d, _ := LookupDay(1)
+	for _, tc := range []struct {
+		inputFile, name string
+	}{
+		{testInput, testInput},
+		{"-", "<stdin>"},
+		{testInput, testInput},
+	} {
+		results := d.results(context.Background(), tc.inputFile, []int{1})
+		if len(results) != 1 || results[0].Input != tc.name || results[0].Err != nil || results[0].Answer.String() != "3" {
+			t.Errorf("%s: got %+v, want a single record of 3 named %s", tc.inputFile, results, tc.name)
+		}
+	}
+}
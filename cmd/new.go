@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// newCmd represents the new command
+var newCmd = &cobra.Command{
+	Use:   "new <day>",
+	Short: "Scaffold a new day: solver, test file and input stubs",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNew,
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+	newCmd.Flags().String("title", "", "short description of the puzzle")
+	newCmd.Flags().String("source-dir", "cmd", "directory holding the day solvers")
+}
+
+var daySourceTemplate = template.Must(template.New("day").Parse(`package cmd
+
+import (
+	"context"
+	"io"
+
+	"dmedinag/adventofcode2025/parse"
+
+	"github.com/rs/zerolog/log"
+)
+
+func init() {
+	Register(Day{
+		Number: {{.Number}},
+		Title:  {{printf "%q" .Title}},
+		New:    func() Solver { return &day{{.Number}}{} },
+	})
+}
+
+type day{{.Number}} struct {
+	lines []parse.Span
+}
+
+func (d *day{{.Number}}) Parse(input io.Reader) error {
+	lines, err := parse.Lines(input)
+	if err != nil {
+		return err
+	}
+	log.Debug().Msgf("Read %d lines", len(lines))
+	d.lines = lines
+	return nil
+}
+
+func (d *day{{.Number}}) Part1(ctx context.Context) (Answer, error) {
+	return Answer{}, errNotImplemented
+}
+
+func (d *day{{.Number}}) Part2(ctx context.Context) (Answer, error) {
+	return Answer{}, errNotImplemented
+}
+`))
+
+var dayTestTemplate = template.Must(template.New("day_test").Parse(`package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDay{{.Number}}(t *testing.T) {
+	d, _ := LookupDay({{.Number}})
+	tests := []struct {
+		name  string
+		input string
+		part1 Answer
+		part2 Answer
+	}{
+		// {name: "example", input: "", part1: IntAnswer(0), part2: IntAnswer(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solutions, err := d.Solve(context.Background(), strings.NewReader(tt.input), 1, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, want := range []Answer{tt.part1, tt.part2} {
+				if solutions[i].Err != nil {
+					t.Fatalf("part %d: %v", i+1, solutions[i].Err)
+				}
+				if got := solutions[i].Answer; !got.Equal(want) {
+					t.Errorf("part %d: got %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+`))
+
+func runNew(cmd *cobra.Command, args []string) error {
+	number, err := strconv.Atoi(args[0])
+	if err != nil || number < 1 || number > 25 {
+		return fmt.Errorf("invalid day %q, expected a number between 1 and 25", args[0])
+	}
+	if _, found := LookupDay(number); found {
+		return fmt.Errorf("day %d is already registered", number)
+	}
+	title, _ := cmd.Flags().GetString("title")
+	sourceDir, _ := cmd.Flags().GetString("source-dir")
+	dir := inputsDir(cmd)
+
+	data := struct {
+		Number int
+		Title  string
+	}{number, title}
+	source, err := renderGo(daySourceTemplate, data)
+	if err != nil {
+		return err
+	}
+	testSource, err := renderGo(dayTestTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	inputFile := filepath.Join(dir, fmt.Sprintf("%02d", number))
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{filepath.Join(sourceDir, fmt.Sprintf("day%d.go", number)), source},
+		{filepath.Join(sourceDir, fmt.Sprintf("day%d_test.go", number)), testSource},
+		{inputFile, nil},
+		{inputFile + "_test", nil},
+		// empty expected answers are skipped until filled in
+		{inputFile + "_test_result", nil},
+	}
+
+	// check everything up front, so that nothing is written if a single file exists
+	existing := []error{}
+	for _, f := range files {
+		if _, err := os.Stat(f.name); err == nil {
+			existing = append(existing, fmt.Errorf("%s already exists", f.name))
+		}
+	}
+	if len(existing) > 0 {
+		return fmt.Errorf("refusing to overwrite: %w", errors.Join(existing...))
+	}
+
+	for _, f := range files {
+		if err := createFile(f.name, f.content); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "created", f.name)
+	}
+	return nil
+}
+
+func renderGo(t *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// createFile writes a new file, failing if it already exists
+func createFile(name string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	return errors.Join(err, f.Close())
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestNewScaffold(t *testing.T) {
+	dir := t.TempDir()
+	sourceDir, inputs := filepath.Join(dir, "cmd"), filepath.Join(dir, "inputs")
+	cmd := &cobra.Command{}
+	cmd.SetOut(io.Discard)
+	cmd.Flags().String("title", "Lobby", "")
+	cmd.Flags().String("source-dir", sourceDir, "")
+	cmd.Flags().String("inputs-dir", inputs, "")
+
+	if err := runNew(cmd, []string{"12"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"day12.go", "day12_test.go"} {
+		source, err := os.ReadFile(filepath.Join(sourceDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if formatted, err := format.Source(source); err != nil || !bytes.Equal(formatted, source) {
+			t.Errorf("%s: got invalid or unformatted Go source (%v):\n%s", name, err, source)
+		}
+		checkImports(t, name, source)
+	}
+	for _, name := range []string{"12", "12_test", "12_test_result"} {
+		if _, err := os.Stat(filepath.Join(inputs, name)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// a single existing file makes it refuse to write any of them
+	os.Remove(filepath.Join(inputs, "12"))
+	if err := runNew(cmd, []string{"12"}); err == nil || !strings.Contains(err.Error(), "day12.go already exists") {
+		t.Errorf("got %v, want a refusal to overwrite the existing files", err)
+	}
+	if _, err := os.Stat(filepath.Join(inputs, "12")); err == nil {
+		t.Error("the missing input was created despite the refusal")
+	}
+
+	if err := runNew(cmd, []string{"4"}); err == nil {
+		t.Error("day 4 is already registered, it can't be scaffolded")
+	}
+}
+
+// checkImports fails unless the source uses exactly the packages it imports, which go/format doesn't check
+func checkImports(t *testing.T, name string, source []byte) {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), name, source, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := map[string]bool{}
+	for _, imp := range f.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		used[importPath[strings.LastIndex(importPath, "/")+1:]] = false
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		// a package name is the only selector operand not declared in the file
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Obj == nil {
+				if _, imported := used[x.Name]; !imported {
+					t.Errorf("%s: %s is used but not imported", name, x.Name)
+				}
+				used[x.Name] = true
+			}
+		}
+		return true
+	})
+	for pkg, isUsed := range used {
+		if !isUsed {
+			t.Errorf("%s: %s is imported but not used", name, pkg)
+		}
+	}
+}
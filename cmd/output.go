@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Result is the outcome of solving one part of a day on one input
+type Result struct {
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   Answer        `json:"answer"`
+	Input    string        `json:"input"`
+	Duration time.Duration `json:"duration_ns"`
+	Err      error         `json:"-"`
+}
+
+// resultWriter emits results on the selected output format
+type resultWriter interface {
+	Write(r Result) error
+	// Flush must be called once all results have been written
+	Flush() error
+}
+
+var outputFormats = []string{"text", "json", "tsv"}
+
+func newResultWriter(format string, w io.Writer) (resultWriter, error) {
+	switch format {
+	case "text":
+		return &textResultWriter{w: w}, nil
+	case "json":
+		return &jsonResultWriter{enc: json.NewEncoder(w)}, nil
+	case "tsv":
+		return &tsvResultWriter{w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, expected one of %v", format, outputFormats)
+}
+
+func (r Result) errString() string {
+	if r.Err == nil {
+		return ""
+	}
+	return r.Err.Error()
+}
+
+type textResultWriter struct {
+	w io.Writer
+}
+
+func (t *textResultWriter) Write(r Result) error {
+	if r.Err != nil {
+		_, err := fmt.Fprintf(t.w, "day %d part %d: error: %v\n", r.Day, r.Part, r.Err)
+		return err
+	}
+	_, err := fmt.Fprintf(t.w, "day %d part %d: %v (%s, %v)\n", r.Day, r.Part, r.Answer, r.Input, r.Duration.Round(time.Microsecond))
+	return err
+}
+
+func (t *textResultWriter) Flush() error {
+	return nil
+}
+
+// jsonResultWriter writes one JSON object per line
+type jsonResultWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonResultWriter) Write(r Result) error {
+	type record struct {
+		Result
+		// Wrapped is set when the answer overflowed int, --bigint gets it right
+		Wrapped bool   `json:"wrapped"`
+		Error   string `json:"error,omitempty"`
+	}
+	return j.enc.Encode(record{Result: r, Wrapped: r.Answer.Wrapped(), Error: r.errString()})
+}
+
+func (j *jsonResultWriter) Flush() error {
+	return nil
+}
+
+// tsvResultWriter writes a header line followed by one tab separated line per result
+type tsvResultWriter struct {
+	w             io.Writer
+	headerWritten bool
+}
+
+func (t *tsvResultWriter) Write(r Result) error {
+	if !t.headerWritten {
+		if _, err := fmt.Fprintln(t.w, "day\tpart\tanswer\tinput\tduration_ns\terror\twrapped"); err != nil {
+			return err
+		}
+		t.headerWritten = true
+	}
+	_, err := fmt.Fprintf(t.w, "%d\t%d\t%v\t%s\t%d\t%s\t%t\n", r.Day, r.Part, r.Answer, r.Input, r.Duration.Nanoseconds(), r.errString(), r.Answer.Wrapped())
+	return err
+}
+
+func (t *tsvResultWriter) Flush() error {
+	return nil
+}
+
+// tableResultWriter aligns results in columns, for human consumption
+type tableResultWriter struct {
+	w             *tabwriter.Writer
+	headerWritten bool
+}
+
+func newTableResultWriter(w io.Writer) *tableResultWriter {
+	return &tableResultWriter{w: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)}
+}
+
+func (t *tableResultWriter) Write(r Result) error {
+	if !t.headerWritten {
+		if _, err := fmt.Fprintln(t.w, "DAY\tPART\tANSWER\tTIME"); err != nil {
+			return err
+		}
+		t.headerWritten = true
+	}
+	answer := r.Answer.String()
+	if r.Err != nil {
+		answer = "error: " + r.Err.Error()
+	}
+	_, err := fmt.Fprintf(t.w, "%d\t%d\t%s\t%v\n", r.Day, r.Part, answer, r.Duration.Round(time.Microsecond))
+	return err
+}
+
+func (t *tableResultWriter) Flush() error {
+	return t.w.Flush()
+}
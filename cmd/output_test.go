@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResultWriters(t *testing.T) {
+	results := []Result{
+		{Day: 1, Part: 1, Answer: IntAnswer(3), Input: "inputs/01", Duration: 1500 * time.Nanosecond},
+		{Day: 1, Part: 2, Input: "inputs/01", Err: errors.New("boom")},
+	}
+	for format, want := range map[string]string{
+		"text": "day 1 part 1: 3 (inputs/01, 2µs)\n" +
+			"day 1 part 2: error: boom\n",
+		"json": `{"day":1,"part":1,"answer":3,"input":"inputs/01","duration_ns":1500,"wrapped":false}` + "\n" +
+			`{"day":1,"part":2,"answer":0,"input":"inputs/01","duration_ns":0,"wrapped":false,"error":"boom"}` + "\n",
+		"tsv": "day\tpart\tanswer\tinput\tduration_ns\terror\twrapped\n" +
+			"1\t1\t3\tinputs/01\t1500\t\tfalse\n" +
+			"1\t2\t0\tinputs/01\t0\tboom\tfalse\n",
+	} {
+		var out strings.Builder
+		w, err := newResultWriter(format, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range results {
+			if err := w.Write(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != want {
+			t.Errorf("%s: got\n%s\nwant\n%s", format, out.String(), want)
+		}
+	}
+	if _, err := newResultWriter("xml", &strings.Builder{}); err == nil {
+		t.Error("got no error, want the unknown format rejected")
+	}
+}
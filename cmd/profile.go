@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// stopProfiling flushes the profiles started by startProfiling, it's a no-op if none is running
+var stopProfiling = func() error { return nil }
+
+// startProfiling starts the CPU profile and execution trace requested on the command line.
+// The memory profile is written when stopProfiling is called.
+func startProfiling(cmd *cobra.Command) error {
+	cpuProfile, _ := cmd.Flags().GetString("cpuprofile")
+	memProfile, _ := cmd.Flags().GetString("memprofile")
+	traceFile, _ := cmd.Flags().GetString("trace")
+
+	stops := []func() error{}
+	stop := func() error {
+		var errs []error
+		// stop in reverse order of start
+		for i := len(stops) - 1; i >= 0; i-- {
+			errs = append(errs, stops[i]())
+		}
+		stops = nil
+		return errors.Join(errs...)
+	}
+
+	if cpuProfile != "" {
+		f, err := os.Create(cpuProfile)
+		if err != nil {
+			return err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return err
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+
+	if traceFile != "" {
+		f, err := os.Create(traceFile)
+		if err != nil {
+			return errors.Join(err, stop())
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return errors.Join(err, stop())
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+
+	if memProfile != "" {
+		stops = append(stops, func() error {
+			f, err := os.Create(memProfile)
+			if err != nil {
+				return err
+			}
+			// get up-to-date statistics
+			runtime.GC()
+			return errors.Join(pprof.Lookup("allocs").WriteTo(f, 0), f.Close())
+		})
+	}
+
+	stopProfiling = stop
+	return nil
+}
+
+// withPartLabels runs f with pprof labels identifying the day and part, inherited by every goroutine f starts
+func withPartLabels(ctx context.Context, day, part int, f func(context.Context)) {
+	withLabels(ctx, day, strconv.Itoa(part), f)
+}
+
+// withLabels is like withPartLabels, for stages other than solving a part, such as parsing
+func withLabels(ctx context.Context, day int, stage string, f func(context.Context)) {
+	labels := pprof.Labels("day", strconv.Itoa(day), "part", stage)
+	pprof.Do(ctx, labels, f)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/pflag"
+)
+
+func TestProfilesWrittenOnFailure(t *testing.T) {
+	d, _ := LookupDay(4)
+	dayCmd := newDayCmd(d)
+	rootCmd.AddCommand(dayCmd)
+	defer func(logger zerolog.Logger, level zerolog.Level) {
+		rootCmd.RemoveCommand(dayCmd)
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		configFiles, dayParams = nil, map[int]map[string]string{}
+		log.Logger = logger
+		zerolog.SetGlobalLevel(level)
+	}(log.Logger, zerolog.GlobalLevel())
+
+	// the configuration of whoever runs the tests doesn't apply
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	for _, s := range settings {
+		if s.env != "" {
+			t.Setenv(s.env, "")
+		}
+	}
+	config := filepath.Join(dir, "config.yaml")
+	input := filepath.Join(dir, "input")
+	for name, content := range map[string]string{config: "", input: "..@\n.x@\n"} {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rootCmd.SetOut(io.Discard)
+
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"day4", "-i", input}, exitInvalidInput},
+		// no input is found for any day
+		{[]string{"run-all", "--inputs-dir", filepath.Join(dir, "missing")}, exitFailure},
+	} {
+		profiles := []string{filepath.Join(dir, "cpu.out"), filepath.Join(dir, "mem.out"), filepath.Join(dir, "trace.out")}
+		for _, profile := range profiles {
+			os.Remove(profile)
+		}
+		rootCmd.SetArgs(append(tc.args, "--config", config, "--log-level", "disabled",
+			"--cpuprofile", profiles[0], "--memprofile", profiles[1], "--trace", profiles[2]))
+
+		err := execute(context.Background())
+		if code := exitCodeOf(err); err == nil || code != tc.code {
+			t.Errorf("%v: got exit code %d (%v), want %d", tc.args, code, err, tc.code)
+		}
+		for _, profile := range profiles {
+			if info, err := os.Stat(profile); err != nil || info.Size() == 0 {
+				t.Errorf("%v: %s: got (%v, %v), want the profile written despite the failure", tc.args, profile, info, err)
+			}
+		}
+		resetFlags()
+	}
+}
+
+// resetFlags restores the flags of the commands, which keep their values from one execution to the next
+func resetFlags() {
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	rootCmd.PersistentFlags().VisitAll(reset)
+	for _, c := range rootCmd.Commands() {
+		c.Flags().VisitAll(reset)
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// Explorer is implemented by solvers whose parsed state can be inspected with the repl command
+type Explorer interface {
+	// ExploreCommands returns the usage of the commands understood by Explore, one per line
+	ExploreCommands() []string
+	// Explore runs a command on the parsed state, returning errUnknownCommand if it isn't supported
+	Explore(ctx context.Context, w io.Writer, command string, args []string) error
+}
+
+var errUnknownCommand = errors.New("unknown command")
+
+// replCmd represents the repl command
+var replCmd = &cobra.Command{
+	Use:   "repl <day>",
+	Short: "Load a day's input and explore its parsed state interactively",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRepl,
+}
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+}
+
+func runRepl(cmd *cobra.Command, args []string) error {
+	d, err := dayFromArg(args[0])
+	if err != nil {
+		return err
+	}
+	inputFiles, err := selectInputFiles(cmd, d.Number)
+	if err != nil {
+		return err
+	}
+	if len(inputFiles) > 1 {
+		log.Warn().Msgf("Exploring only the first of %d inputs", len(inputFiles))
+	}
+	if inputFiles[0] == stdinInput {
+		return errors.New("the standard input is reserved for the repl commands")
+	}
+
+	session := &replSession{day: d, inputFile: inputFiles[0], out: cmd.OutOrStdout()}
+	if err := session.load(); err != nil {
+		return err
+	}
+	if err := session.run(cmd.InOrStdin()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// replSession reads commands one line at a time, running them on the parsed input of a day
+type replSession struct {
+	day       Day
+	inputFile string
+	solver    Solver
+	out       io.Writer
+}
+
+// load parses the input with a fresh solver, discarding any explored state
+func (r *replSession) load() error {
+	s, err := r.day.newSolver()
+	if err != nil {
+		return err
+	}
+	file, err := openInput(r.inputFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := s.Parse(file); err != nil {
+		if parseErr := (*ParseError)(nil); errors.As(err, &parseErr) {
+			parseErr.File = r.inputFile
+		}
+		return err
+	}
+	r.solver = s
+	fmt.Fprintf(r.out, "loaded %s, type help for the available commands\n", r.inputFile)
+	return nil
+}
+
+func (r *replSession) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(r.out, "day%d> ", r.day.Number)
+		if !scanner.Scan() {
+			fmt.Fprintln(r.out)
+			return scanner.Err()
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "quit" || fields[0] == "exit" {
+			return nil
+		}
+		if err := r.exec(fields[0], fields[1:]); err != nil {
+			fmt.Fprintf(r.out, "error: %v\n", err)
+		}
+	}
+}
+
+func (r *replSession) exec(command string, args []string) error {
+	// Ctrl-C interrupts the running command, not the session
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	switch command {
+	case "help":
+		r.help()
+		return nil
+	case "reload":
+		return r.load()
+	case "part1", "part2":
+		solve := r.solver.Part1
+		if command == "part2" {
+			solve = r.solver.Part2
+		}
+		start := time.Now()
+		answer, err := solve(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out, "%v (%v)\n", answer, time.Since(start).Round(time.Microsecond))
+		return nil
+	}
+
+	err := errUnknownCommand
+	if explorer, ok := r.solver.(Explorer); ok {
+		err = explorer.Explore(ctx, r.out, command, args)
+	}
+	if errors.Is(err, errUnknownCommand) {
+		return fmt.Errorf("%w %q, type help for the available commands", errUnknownCommand, command)
+	}
+	return err
+}
+
+func (r *replSession) help() {
+	usages := []string{
+		"part1              solve part 1 on the loaded input",
+		"part2              solve part 2 on the loaded input",
+	}
+	if explorer, ok := r.solver.(Explorer); ok {
+		usages = append(usages, explorer.ExploreCommands()...)
+	}
+	usages = append(usages,
+		"reload             parse the input again, discarding the explored state",
+		"help               print this help",
+		"quit               leave the repl, as does Ctrl-D",
+	)
+	for _, usage := range usages {
+		fmt.Fprintln(r.out, usage)
+	}
+}
+
+// replCount reads the optional repetition count of a repl command, 1 when not given
+func replCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || len(args) > 1 {
+		return 0, fmt.Errorf("invalid count %q, expected a positive number", strings.Join(args, " "))
+	}
+	return n, nil
+}
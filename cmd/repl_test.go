@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplSession(t *testing.T) {
+	d, _ := LookupDay(5)
+	var out strings.Builder
+	session := &replSession{day: d, inputFile: filepath.Join("..", defaultInputsDir, "05_test"), out: &out}
+	if err := session.load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.run(strings.NewReader("query 11\nquery 8\nstep\npart2\nquit\npart1\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"11 is fresh, in [10-20]",
+		"8 is spoiled",
+		`error: unknown command "step"`,
+		"14 (",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in the session:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "3 (") {
+		t.Error("commands after quit must not run")
+	}
+}
@@ -4,44 +4,192 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"os/signal"
+	"runtime"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
+// Exit codes of the day commands
+const (
+	exitFailure      = 1
+	exitInvalidInput = 2
+)
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "adventofcode2025",
 	Short: "A brief description of your application",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// the command line is valid from here on, failures are logged by execute once the profiles are written
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+
+		// stdout is reserved for results, so that they can be piped
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr}).With().Logger()
+
+		if err := loadConfig(cmd); err != nil {
+			log.Fatal().Err(err).Msg("invalid configuration")
+		}
+
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		extraVerbose, _ := cmd.Flags().GetBool("extra-verbose")
+		logLevel, _ := cmd.Flags().GetString("log-level")
 
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+		level, err := zerolog.ParseLevel(logLevel)
+		if err != nil || level == zerolog.NoLevel {
+			log.Fatal().Msgf("invalid log level %q", logLevel)
+		}
+		zerolog.SetGlobalLevel(level)
 		if verbose {
 			zerolog.SetGlobalLevel(zerolog.DebugLevel)
 		}
 		if extraVerbose {
 			zerolog.SetGlobalLevel(zerolog.TraceLevel)
 		}
-		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout}).With().Logger()
+		for _, config := range configFiles {
+			log.Debug().Msgf("Loaded configuration from %s", config.path)
+		}
+
+		nWorkers, _ := cmd.Flags().GetInt("workers")
+		if nWorkers < 1 {
+			log.Fatal().Msgf("invalid number of workers %d, expected at least 1", nWorkers)
+		}
+		workers = newWorkerPool(nWorkers)
+		bigint, _ = cmd.Flags().GetBool("bigint")
+
+		if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(ctx)
+			cancelTimeout = cancel
+		}
+
+		if err := startProfiling(cmd); err != nil {
+			log.Fatal().Err(err).Msg("failed to start profiling")
+		}
 	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
+	for _, d := range Days() {
+		rootCmd.AddCommand(newDayCmd(d))
 	}
+
+	// the first Ctrl-C cancels the running command, letting it report its progress; a second one kills it
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+		log.Warn().Msg("Interrupted, press Ctrl-C again to quit immediately")
+	}()
+
+	if err := execute(ctx); err != nil {
+		os.Exit(exitCodeOf(err))
+	}
+}
+
+// execute runs the command line, then releases the --timeout deadline and writes the profiles,
+// also when the command failed: a slow or cancelled run is when they are needed the most
+func execute(ctx context.Context) error {
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if err != nil && cmd.SilenceErrors {
+		log.Error().Msg(err.Error())
+	}
+	cancelTimeout()
+	if stopErr := stopProfiling(); stopErr != nil {
+		log.Error().Err(stopErr).Msg("failed to write profiles")
+		err = cmp.Or(err, stopErr)
+	}
+	return err
 }
 
+// cancelTimeout releases the deadline set by --timeout
+var cancelTimeout context.CancelFunc = func() {}
+
 func init() {
-	rootCmd.PersistentFlags().BoolP("follow-up", "f", false, "runs the follow up")
-	rootCmd.PersistentFlags().StringP("input-file", "i", "inputs/01", "select file to parse")
+	rootCmd.PersistentFlags().String("config", "", "configuration file (defaults to "+projectConfigFile+", then $XDG_CONFIG_HOME/adventofcode2025/config.yaml)")
+	rootCmd.PersistentFlags().StringP("part", "p", "1", "part to solve: 1, 2 or both, parsing the input only once")
+	rootCmd.PersistentFlags().StringSliceP("input-file", "i", nil, "select files to parse, - for stdin (defaults to the day's input in the inputs directory)")
+	rootCmd.PersistentFlags().String("inputs-dir", defaultInputsDir, "directory holding the puzzle inputs (overrides $"+inputsDirEnv+")")
+	rootCmd.PersistentFlags().Bool("test", false, "use the day's test input instead of the actual one")
+	rootCmd.PersistentFlags().StringP("output", "o", "text", fmt.Sprintf("result output format, one of %v", outputFormats))
+	rootCmd.PersistentFlags().Int("workers", runtime.NumCPU(), "maximum number of goroutines solving a puzzle, shared by all days (1 solves sequentially)")
+	rootCmd.PersistentFlags().Bool("bigint", false, "compute the answers of days 2, 3, 5, 6 and 7 with arbitrary precision, for inputs overflowing int")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "stop solving after this long, reporting the progress made (0 means no limit)")
+	rootCmd.PersistentFlags().String("cpuprofile", "", "write a CPU profile to this file")
+	rootCmd.PersistentFlags().String("memprofile", "", "write a memory allocations profile to this file")
+	rootCmd.PersistentFlags().String("trace", "", "write an execution trace to this file")
+	rootCmd.PersistentFlags().String("log-level", "info", "minimum level of the logs (overridden by --verbose and --extra-verbose)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "enable debug logging")
 	rootCmd.PersistentFlags().BoolP("extra-verbose", "t", false, "enable trace logging")
 }
+
+// newDayCmd builds the subcommand running a registered day
+func newDayCmd(d Day) *cobra.Command {
+	dayCmd := &cobra.Command{
+		Use:   fmt.Sprintf("day%d", d.Number),
+		Short: d.Title,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			check, _ := cmd.Flags().GetBool("check")
+			if check {
+				mismatches, err := verifyDay(cmd.Context(), d, inputsDir(cmd), cmd.OutOrStdout())
+				if err != nil {
+					return err
+				}
+				if mismatches > 0 {
+					return fmt.Errorf("%d answers failed or do not match the expected results", mismatches)
+				}
+				return nil
+			}
+
+			format, _ := cmd.Flags().GetString("output")
+			writer, err := newResultWriter(format, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			inputFiles, err := selectInputFiles(cmd, d.Number)
+			if err != nil {
+				return err
+			}
+			partFlag, _ := cmd.Flags().GetString("part")
+			parts, err := parseParts(partFlag)
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			exitCode := exitFailure
+			for _, inputFile := range inputFiles {
+				for _, result := range d.results(cmd.Context(), inputFile, parts) {
+					if result.Err != nil {
+						failed++
+						if parseErr := (*ParseError)(nil); errors.As(result.Err, &parseErr) {
+							exitCode = exitInvalidInput
+						}
+					}
+					if err := writer.Write(result); err != nil {
+						return err
+					}
+				}
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return &exitError{code: exitCode, err: fmt.Errorf("%d of %d answers failed", failed, len(inputFiles)*len(parts))}
+			}
+			return nil
+		},
+	}
+	dayCmd.Flags().Bool("check", false, "verify both parts on the test input against the expected results")
+	return dayCmd
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// runAllCmd represents the run-all command
+var runAllCmd = &cobra.Command{
+	Use:   "run-all",
+	Short: "Run both parts of every day on its input and print a results table",
+	RunE:  runAll,
+}
+
+func init() {
+	rootCmd.AddCommand(runAllCmd)
+	runAllCmd.Flags().IntP("jobs", "j", runtime.NumCPU(), "maximum number of days solved in parallel")
+}
+
+func runAll(cmd *cobra.Command, args []string) error {
+	jobs, _ := cmd.Flags().GetInt("jobs")
+	if jobs < 1 {
+		return fmt.Errorf("--jobs must be at least 1, got %d", jobs)
+	}
+	format, _ := cmd.Flags().GetString("output")
+	var writer resultWriter = newTableResultWriter(cmd.OutOrStdout())
+	if format != "text" {
+		var err error
+		if writer, err = newResultWriter(format, cmd.OutOrStdout()); err != nil {
+			return err
+		}
+	}
+
+	if inputFiles, _ := cmd.Flags().GetStringSlice("input-file"); len(inputFiles) > 0 {
+		return errors.New("run-all solves every day on its own input, --input-file can't be used")
+	}
+	test, _ := cmd.Flags().GetBool("test")
+
+	results := solveAll(cmd.Context(), Days(), inputsDir(cmd), test, jobs)
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+		if err := writer.Write(r); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d parts failed", failed)
+	}
+	return nil
+}
+
+// solveAll solves both parts of the days on their inputs under dir, or their test inputs, at most jobs days
+// at a time. The results are in the order of the days, part 1 first.
+func solveAll(ctx context.Context, days []Day, dir string, test bool, jobs int) []Result {
+	results := make([]Result, 2*len(days))
+	slots := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+
+	for i, d := range days {
+		// both parts share a single parse of the input
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			inputFile, err := resolveInput(dir, d.Number, test)
+			if err != nil {
+				for part := 1; part <= 2; part++ {
+					results[2*i+part-1] = Result{Day: d.Number, Part: part, Err: err}
+				}
+				return
+			}
+			copy(results[2*i:], d.results(ctx, inputFile, []int{1, 2}))
+		})
+	}
+	wg.Wait()
+	return results
+}
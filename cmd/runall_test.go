@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSolveAllOrder(t *testing.T) {
+	dir := t.TempDir()
+	for day, name := range map[int]string{1: "01", 5: "05"} {
+		content, err := os.ReadFile(filepath.Join("..", defaultInputsDir, name+"_test"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatalf("day %d: %v", day, err)
+		}
+	}
+	want := map[int][2]string{1: {"3", "6"}, 5: {"3", "14"}}
+
+	days := Days()
+	results := solveAll(context.Background(), days, dir, false, 4)
+	if len(results) != 2*len(days) {
+		t.Fatalf("got %d results, want both parts of %d days", len(results), len(days))
+	}
+	for i, r := range results {
+		if d, part := days[i/2].Number, i%2+1; r.Day != d || r.Part != part {
+			t.Fatalf("result %d is day %d part %d, want day %d part %d", i, r.Day, r.Part, d, part)
+		}
+		answers, found := want[r.Day]
+		switch {
+		case !found && r.Err == nil:
+			t.Errorf("day %d part %d: got %v, want an error as it has no input", r.Day, r.Part, r.Answer)
+		case found && (r.Err != nil || r.Answer.String() != answers[r.Part-1]):
+			t.Errorf("day %d part %d: got (%v, %v), want %s", r.Day, r.Part, r.Answer, r.Err, answers[r.Part-1])
+		}
+	}
+}
+
+func TestSolveAllTestInputs(t *testing.T) {
+	d, _ := LookupDay(1)
+	results := solveAll(context.Background(), []Day{d}, filepath.Join("..", defaultInputsDir), true, 1)
+	if results[0].Input != filepath.Join("..", defaultInputsDir, "01_test") || results[0].Answer.String() != "3" {
+		t.Errorf("got %v on %s, want 3 from the test input", results[0].Answer, results[0].Input)
+	}
+}
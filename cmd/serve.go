@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// Renderer is implemented by solvers able to draw their parsed input on the dashboard
+type Renderer interface {
+	// Render writes a self-contained SVG image
+	Render(ctx context.Context, w io.Writer) error
+}
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a local dashboard with the answers, timings, verification status and renderings of every day",
+	Args:  cobra.NoArgs,
+	RunE:  runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String("addr", "localhost:8025", "address to listen on")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newDashboard(inputsDir(cmd)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-cmd.Context().Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to shut down the dashboard")
+		}
+	}()
+
+	log.Info().Msgf("Serving the dashboard on http://%s, press Ctrl-C to stop", addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// newDashboard returns the handler of the dashboard, solving the days on every request so that it reflects
+// the current inputs
+func newDashboard(dir string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		reports := []dayReport{}
+		for _, d := range Days() {
+			reports = append(reports, buildDayReport(r.Context(), d, dir, false, false))
+		}
+		renderPage(w, dashboardIndex, reports)
+	})
+	mux.HandleFunc("GET /day/{day}", func(w http.ResponseWriter, r *http.Request) {
+		d, err := dayFromArg(r.PathValue("day"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		test := r.URL.Query().Get("input") == "test"
+		renderPage(w, dashboardDay, buildDayReport(r.Context(), d, dir, test, true))
+	})
+	return mux
+}
+
+func renderPage(w http.ResponseWriter, page *template.Template, data any) {
+	// rendered first, so that a template failure doesn't send half a page
+	var buf bytes.Buffer
+	if err := page.Execute(&buf, data); err != nil {
+		log.Error().Err(err).Msg("failed to render the dashboard")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// dayReport is everything the dashboard shows about a day
+type dayReport struct {
+	Day       Day
+	Test      bool
+	Input     inputSummary
+	Results   []Result
+	TestInput string
+	Checks    []partCheck
+	CheckErr  error
+	Rendering template.HTML
+	RenderErr error
+}
+
+// inputSummary describes an input file
+type inputSummary struct {
+	Path  string
+	Bytes int
+	Lines int
+	Err   error
+}
+
+// Verified tells whether every known expected answer matches, and at least one is known
+func (r dayReport) Verified() bool {
+	known := 0
+	for _, c := range r.Checks {
+		switch c.Status() {
+		case "ok":
+			known++
+		case "SKIP":
+		default:
+			return false
+		}
+	}
+	return known > 0
+}
+
+// buildDayReport solves both parts of a day on its actual or test input, and verifies it on the test input
+func buildDayReport(ctx context.Context, d Day, dir string, test, render bool) dayReport {
+	report := dayReport{Day: d, Test: test}
+
+	report.TestInput, report.Checks, report.CheckErr = checkDay(ctx, d, dir)
+
+	inputFile, err := resolveInput(dir, d.Number, test)
+	if err != nil {
+		report.Input.Err = err
+		return report
+	}
+	report.Input.Path = inputFile
+	content, err := os.ReadFile(inputFile)
+	if err != nil {
+		report.Input.Err = err
+		return report
+	}
+	report.Input.Bytes = len(content)
+	report.Input.Lines = bytes.Count(content, []byte("\n"))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		report.Input.Lines++
+	}
+
+	report.Results = d.results(ctx, inputFile, []int{1, 2})
+
+	if render {
+		report.Rendering, report.RenderErr = renderDay(ctx, d, content)
+	}
+	return report
+}
+
+// renderDay parses the input and draws it, if the day's solver is a Renderer
+func renderDay(ctx context.Context, d Day, input []byte) (template.HTML, error) {
+	s, err := d.newSolver()
+	if err != nil {
+		return "", err
+	}
+	renderer, ok := s.(Renderer)
+	if !ok {
+		return "", nil
+	}
+	if err := s.Parse(bytes.NewReader(input)); err != nil {
+		return "", err
+	}
+	var svg bytes.Buffer
+	if err := renderer.Render(ctx, &svg); err != nil {
+		return "", err
+	}
+	// the SVG is generated by the solver from parsed numbers and positions only
+	return template.HTML(svg.String()), nil
+}
+
+var dashboardLayout = template.Must(template.New("layout").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Advent of Code 2025</title>
+<style>
+body { font-family: ui-monospace, monospace; background: #1a1b26; color: #c0caf5; margin: 2em auto; max-width: 70em; padding: 0 1em; }
+a { color: #7aa2f7; }
+h1 a { color: inherit; text-decoration: none; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border-bottom: 1px solid #3b4261; padding: .3em .8em; text-align: left; }
+.ok { color: #9ece6a; }
+.fail { color: #f7768e; }
+.skip { color: #737aa2; }
+.rendering { overflow: auto; max-height: 80vh; border: 1px solid #3b4261; }
+</style>
+</head>
+<body>
+<h1><a href="/">Advent of Code 2025</a></h1>
+{{template "content" .}}
+</body>
+</html>
+{{define "status"}}{{if eq . "ok"}}<span class="ok">ok</span>{{else if eq . "SKIP"}}<span class="skip">skip</span>{{else}}<span class="fail">{{.}}</span>{{end}}{{end}}
+{{define "answer"}}{{if .Err}}<span class="fail">{{.Err}}</span>{{else}}{{.Answer}}{{end}}{{end}}
+`))
+
+var dashboardIndex = template.Must(template.Must(dashboardLayout.Clone()).Parse(`{{define "content"}}
+<table>
+<tr><th>day</th><th>title</th><th>part 1</th><th>part 2</th><th>time</th><th>test input</th></tr>
+{{range .}}
+<tr>
+<td><a href="/day/{{.Day.Number}}">{{.Day.Number}}</a></td>
+<td>{{.Day.Title}}</td>
+{{if .Input.Err}}<td colspan="3" class="fail">{{.Input.Err}}</td>
+{{else}}{{range .Results}}<td>{{template "answer" .}}</td>{{end}}
+<td>{{with index .Results 0}}{{.Duration}}{{end}} / {{with index .Results 1}}{{.Duration}}{{end}}</td>
+{{end}}
+<td>{{if .CheckErr}}<span class="skip">unverified</span>{{else if .Verified}}<span class="ok">verified</span>{{else}}<span class="fail">failing</span>{{end}}</td>
+</tr>
+{{end}}
+</table>
+{{end}}`))
+
+var dashboardDay = template.Must(template.Must(dashboardLayout.Clone()).Parse(`{{define "content"}}
+<h2>Day {{.Day.Number}}: {{.Day.Title}}</h2>
+<p>{{if .Test}}test input, <a href="?">show the actual input</a>{{else}}actual input, <a href="?input=test">show the test input</a>{{end}}</p>
+
+<h3>Input</h3>
+{{if .Input.Err}}<p class="fail">{{.Input.Err}}</p>
+{{else}}<p>{{.Input.Path}}: {{.Input.Lines}} lines, {{.Input.Bytes}} bytes</p>
+
+<h3>Answers</h3>
+<table>
+<tr><th>part</th><th>answer</th><th>time</th></tr>
+{{range .Results}}<tr><td>{{.Part}}</td><td>{{template "answer" .}}</td><td>{{.Duration}}</td></tr>
+{{end}}
+</table>
+{{end}}
+
+<h3>Verification</h3>
+{{if .CheckErr}}<p class="skip">{{.CheckErr}}</p>
+{{else}}<table>
+<tr><th>part</th><th>expected</th><th>got</th><th>status</th></tr>
+{{range .Checks}}<tr><td>{{.Part}}</td><td>{{.Expected}}</td><td>{{if .Err}}{{.Err}}{{else}}{{.Got}}{{end}}</td><td>{{template "status" .Status}}</td></tr>
+{{end}}
+</table>
+<p>expected results from {{.TestInput}}_result</p>
+{{end}}
+
+{{if .RenderErr}}<h3>Rendering</h3><p class="fail">{{.RenderErr}}</p>
+{{else if .Rendering}}<h3>Rendering</h3><div class="rendering">{{.Rendering}}</div>
+{{end}}
+{{end}}`))
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDashboard(t *testing.T) {
+	server := httptest.NewServer(newDashboard(filepath.Join("..", defaultInputsDir)))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		path   string
+		status int
+		want   []string
+	}{
+		{"/", http.StatusOK, []string{`<a href="/day/4">4</a>`, "verified"}},
+		{"/day/4?input=test", http.StatusOK, []string{"04_test: 10 lines", "<td>13</td>", "<svg"}},
+		{"/day/7?input=test", http.StatusOK, []string{"<td>40</td>", "<svg"}},
+		{"/day/99", http.StatusNotFound, nil},
+	} {
+		resp, err := http.Get(server.URL + tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: got status %d, want %d", tc.path, resp.StatusCode, tc.status)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(string(body), want) {
+				t.Errorf("%s: missing %q", tc.path, want)
+			}
+		}
+	}
+}
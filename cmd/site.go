@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// Environment variables configuring the puzzle website
+const (
+	baseURLEnv = "AOC_BASE_URL"
+	sessionEnv = "AOC_SESSION"
+)
+
+const defaultBaseURL = "https://adventofcode.com/2025"
+
+func addPuzzleSiteFlags(c *cobra.Command) {
+	c.Flags().String("base-url", defaultBaseURL, "puzzle website, inputs are fetched from <base-url>/day/<day>/input (overrides $"+baseURLEnv+")")
+	c.Flags().Duration("min-interval", 5*time.Second, "minimum time between two requests to the website")
+}
+
+func puzzleSiteFromFlags(cmd *cobra.Command) (*puzzleSite, error) {
+	session := configSetting("session")
+	if session == "" {
+		return nil, fmt.Errorf("$%s or the session configuration setting must hold the session token of the puzzle website", sessionEnv)
+	}
+	baseURL, _ := cmd.Flags().GetString("base-url")
+	minInterval, _ := cmd.Flags().GetDuration("min-interval")
+	return newPuzzleSite(baseURL, session, inputsDir(cmd), minInterval), nil
+}
+
+// puzzleSite talks to the puzzle website: it downloads inputs, caching them in a directory
+// with the inputs naming convention, and submits answers
+type puzzleSite struct {
+	client  *http.Client
+	baseURL string
+	session string
+	dir     string
+	// minInterval is enforced across invocations, remembering when the last request was made in stampFile
+	minInterval time.Duration
+	stampFile   string
+	now         func() time.Time
+	sleep       func(time.Duration)
+}
+
+func newPuzzleSite(baseURL, session, dir string, minInterval time.Duration) *puzzleSite {
+	return &puzzleSite{
+		client:      &http.Client{Timeout: 30 * time.Second},
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		session:     session,
+		dir:         dir,
+		minInterval: minInterval,
+		stampFile:   filepath.Join(dir, ".last_fetch"),
+		now:         time.Now,
+		sleep:       time.Sleep,
+	}
+}
+
+// Fetch returns the path of a day's input, downloading it first if it isn't cached
+func (f *puzzleSite) Fetch(ctx context.Context, day int) (path string, downloaded bool, err error) {
+	path = filepath.Join(f.dir, fmt.Sprintf("%02d", day))
+	if _, err := os.Stat(path); err == nil {
+		return path, false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/day/%d/input", f.baseURL, day), nil)
+	if err != nil {
+		return "", false, err
+	}
+	resp, err := f.do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("fetching day %d input: %w", day, err)
+	}
+	defer resp.Body.Close()
+
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return "", false, err
+	}
+	// download next to the final path, so that a failure never leaves a truncated input in the cache
+	tmp, err := os.CreateTemp(f.dir, fmt.Sprintf(".%02d-*", day))
+	if err != nil {
+		return "", false, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return "", false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", false, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", false, err
+	}
+	return path, true, nil
+}
+
+// Submit posts the answer to a part of a day, and returns the verdict along with the website's message
+func (f *puzzleSite) Submit(ctx context.Context, day, part int, answer string) (verdict, string, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/day/%d/answer", f.baseURL, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return verdictUnknown, "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := f.do(req)
+	if err != nil {
+		return verdictUnknown, "", fmt.Errorf("submitting day %d part %d: %w", day, part, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return verdictUnknown, "", err
+	}
+	message := articleText(string(body))
+	return parseVerdict(message), message, nil
+}
+
+// do sends an authenticated request once the rate limit allows it, failing on any status other than 200
+func (f *puzzleSite) do(req *http.Request) (*http.Response, error) {
+	f.waitTurn()
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.session})
+	req.Header.Set("User-Agent", "github.com/dmedinag/advent-of-code-25")
+
+	resp, err := f.client.Do(req)
+	f.recordRequest()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, errors.New(resp.Status + ": " + strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// waitTurn sleeps until minInterval has passed since the last recorded request
+func (f *puzzleSite) waitTurn() {
+	content, err := os.ReadFile(f.stampFile)
+	if err != nil {
+		return
+	}
+	last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(content)))
+	if err != nil {
+		return
+	}
+	if wait := last.Add(f.minInterval).Sub(f.now()); wait > 0 {
+		log.Info().Msgf("Waiting %v before the next request", wait.Round(time.Millisecond))
+		f.sleep(wait)
+	}
+}
+
+func (f *puzzleSite) recordRequest() {
+	err := os.MkdirAll(filepath.Dir(f.stampFile), 0o755)
+	if err == nil {
+		err = os.WriteFile(f.stampFile, []byte(f.now().Format(time.RFC3339Nano)+"\n"), 0o644)
+	}
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to record the request time, the rate limit won't apply to the next run")
+	}
+}
+
+// articleText extracts the text of the <article> element holding the website's reply, without markup
+func articleText(page string) string {
+	if start := strings.Index(page, "<article"); start >= 0 {
+		page = page[start:]
+		if end := strings.Index(page, "</article>"); end >= 0 {
+			page = page[:end]
+		}
+	}
+	text := strings.Builder{}
+	inTag := false
+	for _, r := range page {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag:
+			text.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+func parseVerdict(message string) verdict {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "that's the right answer"):
+		return verdictCorrect
+	case strings.Contains(lower, "too high"):
+		return verdictTooHigh
+	case strings.Contains(lower, "too low"):
+		return verdictTooLow
+	case strings.Contains(lower, "not the right answer"):
+		return verdictWrong
+	case strings.Contains(lower, "answer too recently"):
+		return verdictThrottled
+	}
+	return verdictUnknown
+}
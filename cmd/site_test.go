@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPuzzleSiteFetch(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/2025/day/3/input":
+			w.Write([]byte("987654321111111\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	now := time.Date(2025, 12, 3, 5, 0, 0, 0, time.UTC)
+	slept := []time.Duration{}
+	newFetcher := func(session string) *puzzleSite {
+		f := newPuzzleSite(server.URL+"/2025/", session, dir, 5*time.Second)
+		f.now = func() time.Time { return now }
+		f.sleep = func(d time.Duration) { slept = append(slept, d) }
+		return f
+	}
+
+	path, downloaded, err := newFetcher("secret").Fetch(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "03"); path != want || !downloaded {
+		t.Errorf("got (%s, %v), want (%s, true)", path, downloaded, want)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "987654321111111\n" {
+		t.Errorf("unexpected cached content %q", content)
+	}
+
+	// cached inputs are never downloaded again
+	if _, downloaded, err := newFetcher("secret").Fetch(context.Background(), 3); err != nil || downloaded {
+		t.Errorf("got (%v, %v), want the cached input", downloaded, err)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+
+	// the next request waits for the rest of the interval, even from another fetcher
+	now = now.Add(2 * time.Second)
+	if _, _, err := newFetcher("wrong").Fetch(context.Background(), 4); err == nil {
+		t.Error("expected an error for a rejected session")
+	}
+	if len(slept) != 1 || slept[0] != 3*time.Second {
+		t.Errorf("got sleeps %v, want [3s]", slept)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "04")); !os.IsNotExist(err) {
+		t.Errorf("failed download left a cached input behind: %v", err)
+	}
+}
+
+func TestPuzzleSiteSubmit(t *testing.T) {
+	replies := map[string]string{
+		"1":  "That's the right answer! You are one gold star closer to decorating the North Pole.",
+		"99": "That's not the right answer; your answer is too high.",
+		"2":  "That's not the right answer; your answer is too low.",
+		"7":  "That's not the right answer. If you're stuck, make sure you're using the full input data.",
+		"5":  "You gave an answer too recently; you have to wait after submitting an answer before trying again.",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/day/4/answer" || r.FormValue("level") != "2" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("<html><main><article><p>" + replies[r.FormValue("answer")] + "</p></article></main></html>"))
+	}))
+	defer server.Close()
+
+	site := newPuzzleSite(server.URL, "secret", t.TempDir(), 0)
+	for answer, want := range map[string]verdict{
+		"1":  verdictCorrect,
+		"99": verdictTooHigh,
+		"2":  verdictTooLow,
+		"7":  verdictWrong,
+		"5":  verdictThrottled,
+	} {
+		got, message, err := site.Submit(context.Background(), 4, 2, answer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("answer %s: got %s, want %s (message %q)", answer, got, want, message)
+		}
+	}
+}
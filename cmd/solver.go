@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Solver holds the parsed input of a day's puzzle and computes both parts from it.
+// Parse must be called before Part1 or Part2. Malformed input is reported as a *ParseError.
+// Parts stop as soon as ctx is done, returning a *CancelledError with the progress made.
+type Solver interface {
+	Parse(input io.Reader) error
+	Part1(ctx context.Context) (Answer, error)
+	Part2(ctx context.Context) (Answer, error)
+}
+
+// Configurable is implemented by solvers accepting parameters, e.g. from the configuration file
+type Configurable interface {
+	Configure(params map[string]string) error
+}
+
+// Day describes a registered puzzle
+type Day struct {
+	Number int
+	Title  string
+	New    func() Solver
+}
+
+// newSolver returns a fresh solver, configured with the day's parameters from the configuration files
+func (d Day) newSolver() (Solver, error) {
+	s := d.New()
+	if c, ok := s.(Configurable); ok && len(dayParams[d.Number]) > 0 {
+		if err := c.Configure(dayParams[d.Number]); err != nil {
+			return nil, fmt.Errorf("day %d parameters: %w", d.Number, err)
+		}
+	}
+	return s, nil
+}
+
+// Solution is the answer to one part, along with the time it took to compute it from the parsed input
+type Solution struct {
+	Part     int
+	Answer   Answer
+	Duration time.Duration
+	Err      error
+}
+
+// Solve parses input once with a fresh solver, then computes the answers of the given parts from it.
+// The error is only set when the input can't be parsed, failures of a part are reported in its Solution.
+func (d Day) Solve(ctx context.Context, input io.Reader, parts ...int) ([]Solution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var s Solver
+	var err error
+	withLabels(ctx, d.Number, "parse", func(context.Context) {
+		if s, err = d.newSolver(); err != nil {
+			return
+		}
+		start := time.Now()
+		if err = s.Parse(input); err == nil {
+			log.Debug().Msgf("Parsed day %d input in %v", d.Number, time.Since(start))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	solutions := make([]Solution, len(parts))
+	for i, part := range parts {
+		solutions[i].Part = part
+		withPartLabels(ctx, d.Number, part, func(ctx context.Context) {
+			start := time.Now()
+			switch part {
+			case 1:
+				solutions[i].Answer, solutions[i].Err = s.Part1(ctx)
+			case 2:
+				solutions[i].Answer, solutions[i].Err = s.Part2(ctx)
+			default:
+				solutions[i].Err = fmt.Errorf("day %d has no part %d", d.Number, part)
+			}
+			solutions[i].Duration = time.Since(start)
+		})
+		if answer := solutions[i].Answer; solutions[i].Err == nil {
+			if answer.Wrapped() {
+				log.Warn().Msgf("Day %d part %d overflowed int, solve it with --bigint for the exact answer", d.Number, part)
+			} else if answer.Overflows() {
+				log.Warn().Msgf("Day %d part %d doesn't fit in an int, natively it would have wrapped to %d", d.Number, part, answer.Native())
+			}
+		}
+	}
+	return solutions, nil
+}
+
+// SolveFile is like Solve, but reads the input from the given file, or from stdin if filename is "-"
+func (d Day) SolveFile(ctx context.Context, filename string, parts ...int) ([]Solution, error) {
+	file, err := openInput(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	solutions, err := d.Solve(ctx, file, parts...)
+	if parseErr := (*ParseError)(nil); errors.As(err, &parseErr) {
+		parseErr.File = inputName(filename)
+	}
+	return solutions, err
+}
+
+// results solves the parts of the day on an input file, returning one Result per part
+// even when the input can't be read or parsed
+func (d Day) results(ctx context.Context, inputFile string, parts []int) []Result {
+	results := make([]Result, len(parts))
+	solutions, err := d.SolveFile(ctx, inputFile, parts...)
+	for i, part := range parts {
+		results[i] = Result{Day: d.Number, Part: part, Input: inputName(inputFile), Err: err}
+		if err == nil {
+			results[i].Answer, results[i].Duration, results[i].Err = solutions[i].Answer, solutions[i].Duration, solutions[i].Err
+		}
+	}
+	return results
+}
+
+// parseParts reads the value of the --part flag: 1, 2 or both
+func parseParts(value string) ([]int, error) {
+	switch value {
+	case "1":
+		return []int{1}, nil
+	case "2":
+		return []int{2}, nil
+	case "both":
+		return []int{1, 2}, nil
+	}
+	return nil, fmt.Errorf("invalid part %q, expected 1, 2 or both", value)
+}
+
+var registry = map[int]Day{}
+
+// Register makes a day available to the CLI and to LookupDay. It panics if the day number is already taken.
+func Register(d Day) {
+	if _, exists := registry[d.Number]; exists {
+		panic(fmt.Sprintf("day %d registered twice", d.Number))
+	}
+	registry[d.Number] = d
+}
+
+// LookupDay returns the registered day with the given number
+func LookupDay(number int) (Day, bool) {
+	d, found := registry[number]
+	return d, found
+}
+
+// dayFromArg looks up the day named by a command argument, either as "5" or "day5"
+func dayFromArg(arg string) (Day, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(arg, "day"))
+	if err != nil {
+		return Day{}, fmt.Errorf("invalid day %q", arg)
+	}
+	d, found := LookupDay(number)
+	if !found {
+		return Day{}, fmt.Errorf("day %d is not registered", number)
+	}
+	return d, nil
+}
+
+// Days returns all registered days, sorted by number
+func Days() []Day {
+	days := make([]Day, 0, len(registry))
+	for _, d := range registry {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Number < days[j].Number
+	})
+	return days
+}
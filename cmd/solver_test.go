@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseParts(t *testing.T) {
+	for value, want := range map[string][]int{"1": {1}, "2": {2}, "both": {1, 2}} {
+		if got, err := parseParts(value); err != nil || !slices.Equal(got, want) {
+			t.Errorf("%q: got (%v, %v), want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "3", "1,2", "all"} {
+		if _, err := parseParts(value); err == nil {
+			t.Errorf("%q: got no error, want it rejected", value)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// submitCmd represents the submit command
+var submitCmd = &cobra.Command{
+	Use:   "submit <day> <part> [answer]",
+	Short: "Submit an answer, solving the day on its input when none is given, and record the verdict",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE:  runSubmit,
+}
+
+func init() {
+	rootCmd.AddCommand(submitCmd)
+	addPuzzleSiteFlags(submitCmd)
+	submitCmd.Flags().String("ledger", "", "file recording every submission (defaults to answers.jsonl in the inputs directory)")
+}
+
+// verdict is the website's reply to a submitted answer
+type verdict string
+
+const (
+	verdictCorrect   verdict = "correct"
+	verdictWrong     verdict = "wrong"
+	verdictTooHigh   verdict = "too_high"
+	verdictTooLow    verdict = "too_low"
+	verdictThrottled verdict = "throttled"
+	verdictUnknown   verdict = "unknown"
+)
+
+// isWrong tells whether the answer was rejected, as opposed to not being judged at all
+func (v verdict) isWrong() bool {
+	return v == verdictWrong || v == verdictTooHigh || v == verdictTooLow
+}
+
+// attempt is an entry of the answer ledger
+type attempt struct {
+	Time    time.Time `json:"time,omitzero"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict verdict   `json:"verdict"`
+}
+
+// answerLedger keeps every submission ever made, one JSON object per line
+type answerLedger struct {
+	path     string
+	attempts []attempt
+}
+
+func loadLedger(path string) (*answerLedger, error) {
+	ledger := &answerLedger{path: path}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		a := attempt{}
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			return nil, &ParseError{File: path, Line: line, Text: scanner.Text(), Err: err}
+		}
+		ledger.attempts = append(ledger.attempts, a)
+	}
+	return ledger, scanner.Err()
+}
+
+// Record appends an attempt to the ledger file
+func (l *answerLedger) Record(a attempt) error {
+	content, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(content, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	l.attempts = append(l.attempts, a)
+	return file.Close()
+}
+
+// verdictOf returns the verdict already given to an answer, if it was judged
+func (l *answerLedger) verdictOf(day, part int, answer string) (verdict, bool) {
+	for _, a := range l.attempts {
+		if a.Day == day && a.Part == part && a.Answer == answer && (a.Verdict == verdictCorrect || a.Verdict.isWrong()) {
+			return a.Verdict, true
+		}
+	}
+	return verdictUnknown, false
+}
+
+// bounds returns the highest answer known to be too low and the lowest one known to be too high, nil when unknown
+func (l *answerLedger) bounds(day, part int) (tooLow, tooHigh *big.Int) {
+	for _, a := range l.attempts {
+		if a.Day != day || a.Part != part {
+			continue
+		}
+		value, ok := new(big.Int).SetString(a.Answer, 10)
+		if !ok {
+			continue
+		}
+		switch a.Verdict {
+		case verdictTooLow:
+			if tooLow == nil || value.Cmp(tooLow) > 0 {
+				tooLow = value
+			}
+		case verdictTooHigh:
+			if tooHigh == nil || value.Cmp(tooHigh) < 0 {
+				tooHigh = value
+			}
+		}
+	}
+	return tooLow, tooHigh
+}
+
+func runSubmit(cmd *cobra.Command, args []string) error {
+	d, err := dayFromArg(args[0])
+	if err != nil {
+		return err
+	}
+	part, err := strconv.Atoi(args[1])
+	if err != nil || (part != 1 && part != 2) {
+		return fmt.Errorf("invalid part %q, expected 1 or 2", args[1])
+	}
+
+	var answer string
+	if len(args) == 3 {
+		answer = args[2]
+	} else {
+		inputFile, err := resolveInput(inputsDir(cmd), d.Number, false)
+		if err != nil {
+			return err
+		}
+		solutions, err := d.SolveFile(cmd.Context(), inputFile, part)
+		if err == nil {
+			err = solutions[0].Err
+		}
+		if err != nil {
+			return err
+		}
+		// a wrong answer locks out further submissions for a while
+		if solutions[0].Answer.Wrapped() {
+			return fmt.Errorf("Day %d part %d overflowed int, refusing to submit the wrong %v: rerun with --bigint", d.Number, part, solutions[0].Answer)
+		}
+		answer = solutions[0].Answer.String()
+		log.Info().Msgf("Solved day %d part %d on %s: %s", d.Number, part, inputFile, answer)
+	}
+
+	ledgerFile, _ := cmd.Flags().GetString("ledger")
+	if ledgerFile == "" {
+		ledgerFile = filepath.Join(inputsDir(cmd), "answers.jsonl")
+	}
+	ledger, err := loadLedger(ledgerFile)
+	if err != nil {
+		return err
+	}
+
+	if previous, found := ledger.verdictOf(d.Number, part, answer); found {
+		return fmt.Errorf("%s was already submitted for day %d part %d, and it was %s", answer, d.Number, part, previous)
+	}
+	if value, ok := new(big.Int).SetString(answer, 10); ok {
+		tooLow, tooHigh := ledger.bounds(d.Number, part)
+		if tooLow != nil && value.Cmp(tooLow) <= 0 {
+			log.Warn().Msgf("%s is not above %v, which is known to be too low", answer, tooLow)
+		}
+		if tooHigh != nil && value.Cmp(tooHigh) >= 0 {
+			log.Warn().Msgf("%s is not below %v, which is known to be too high", answer, tooHigh)
+		}
+	}
+
+	site, err := puzzleSiteFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	v, message, err := site.Submit(cmd.Context(), d.Number, part, answer)
+	if err != nil {
+		return err
+	}
+	if err := ledger.Record(attempt{Time: site.now(), Day: d.Number, Part: part, Answer: answer, Verdict: v}); err != nil {
+		log.Error().Err(err).Msg("failed to record the attempt")
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "day %d part %d: %s is %s\n", d.Number, part, answer, v)
+	log.Debug().Msg(message)
+	if v != verdictCorrect {
+		return fmt.Errorf("day %d part %d isn't solved yet", d.Number, part)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAnswerLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.jsonl")
+	ledger, err := loadLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []attempt{
+		{Day: 2, Part: 2, Answer: "41823587595", Verdict: verdictTooHigh},
+		{Day: 2, Part: 2, Answer: "100", Verdict: verdictTooLow},
+		{Day: 2, Part: 2, Answer: "41823587599", Verdict: verdictTooHigh},
+		{Day: 2, Part: 2, Answer: "200", Verdict: verdictThrottled},
+		{Day: 2, Part: 1, Answer: "31210613313", Verdict: verdictCorrect},
+	} {
+		if err := ledger.Record(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// everything survives a reload
+	ledger, err = loadLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledger.attempts) != 5 {
+		t.Fatalf("got %d attempts, want 5", len(ledger.attempts))
+	}
+
+	if v, found := ledger.verdictOf(2, 2, "41823587595"); !found || v != verdictTooHigh {
+		t.Errorf("got (%s, %v), want the known too high verdict", v, found)
+	}
+	if _, found := ledger.verdictOf(2, 2, "200"); found {
+		t.Error("a throttled answer was never judged, it may be submitted again")
+	}
+	if _, found := ledger.verdictOf(2, 1, "41823587595"); found {
+		t.Error("verdicts of another part must not apply")
+	}
+
+	tooLow, tooHigh := ledger.bounds(2, 2)
+	if tooLow == nil || tooLow.String() != "100" || tooHigh == nil || tooHigh.String() != "41823587595" {
+		t.Errorf("got bounds (%v, %v), want (100, 41823587595)", tooLow, tooHigh)
+	}
+	if tooLow, tooHigh := ledger.bounds(2, 1); tooLow != nil || tooHigh != nil {
+		t.Errorf("got bounds (%v, %v), want none", tooLow, tooHigh)
+	}
+}
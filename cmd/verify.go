@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [day...]",
+	Short: "Check answers on the test inputs against the stored expected results",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  runVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	days := Days()
+	if len(args) > 0 {
+		days = []Day{}
+		for _, arg := range args {
+			d, err := dayFromArg(arg)
+			if err != nil {
+				return err
+			}
+			days = append(days, d)
+		}
+	}
+
+	mismatches := 0
+	for _, d := range days {
+		n, err := verifyDay(cmd.Context(), d, inputsDir(cmd), cmd.OutOrStdout())
+		if err != nil {
+			return fmt.Errorf("day %d: %w", d.Number, err)
+		}
+		mismatches += n
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("%d answers failed or do not match the expected results", mismatches)
+	}
+	return nil
+}
+
+// readExpectedAnswers reads a test result file, holding the part 1 answer on the first line
+// and the part 2 answer on the second one. Missing or empty lines mean the answer is unknown.
+func readExpectedAnswers(filename string) ([2]string, error) {
+	expected := [2]string{}
+	file, err := os.Open(filename)
+	if err != nil {
+		return expected, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+
+	for i := 0; i < len(expected) && scanner.Scan(); i++ {
+		expected[i] = strings.TrimSpace(scanner.Text())
+	}
+
+	return expected, scanner.Err()
+}
+
+// partCheck compares the answer to a part on the test input with the expected one
+type partCheck struct {
+	Part     int
+	Expected string // empty if unknown, in which case the part isn't solved
+	Got      string
+	Err      error
+}
+
+// Status is one of ok, FAIL, SKIP or ERROR
+func (c partCheck) Status() string {
+	switch {
+	case c.Err != nil:
+		return "ERROR"
+	case c.Expected == "":
+		return "SKIP"
+	case c.Got == c.Expected:
+		return "ok"
+	}
+	return "FAIL"
+}
+
+// checkDay runs both parts of a day on its test input under dir, comparing them with the expected answers.
+// The expected answers live next to the test input, with a _result suffix.
+// A missing test input is reported as an *InputNotFoundError, and a missing result file as fs.ErrNotExist.
+func checkDay(ctx context.Context, d Day, dir string) (testInput string, checks []partCheck, err error) {
+	testInput, err = resolveInput(dir, d.Number, true)
+	if err != nil {
+		return "", nil, err
+	}
+	expected, err := readExpectedAnswers(testInput + "_result")
+	if err != nil {
+		return testInput, nil, err
+	}
+
+	parts := []int{}
+	for i, want := range expected {
+		checks = append(checks, partCheck{Part: i + 1, Expected: want})
+		if want != "" {
+			parts = append(parts, i+1)
+		}
+	}
+	if len(parts) == 0 {
+		return testInput, checks, nil
+	}
+	solutions, err := d.SolveFile(ctx, testInput, parts...)
+	if err != nil {
+		return testInput, nil, err
+	}
+	for _, solution := range solutions {
+		check := &checks[solution.Part-1]
+		check.Got, check.Err = solution.Answer.String(), solution.Err
+	}
+	return testInput, checks, nil
+}
+
+// verifyDay runs both parts of a day on its test input under dir and reports each comparison to w.
+// It returns how many answers differ from the expected ones or failed, reporting every part either way.
+func verifyDay(ctx context.Context, d Day, dir string, w io.Writer) (int, error) {
+	testInput, checks, err := checkDay(ctx, d, dir)
+	if notFound := (*InputNotFoundError)(nil); errors.As(err, &notFound) {
+		fmt.Fprintf(w, "day %d: SKIP (%v)\n", d.Number, err)
+		return 0, nil
+	}
+	resultFile := testInput + "_result"
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(w, "day %d: SKIP (no %s)\n", d.Number, resultFile)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	mismatches := 0
+	for _, check := range checks {
+		switch check.Status() {
+		case "ERROR":
+			mismatches++
+			fmt.Fprintf(w, "day %d part %d: ERROR (%v)\n", d.Number, check.Part, check.Err)
+		case "SKIP":
+			fmt.Fprintf(w, "day %d part %d: SKIP (no expected answer)\n", d.Number, check.Part)
+		case "ok":
+			fmt.Fprintf(w, "day %d part %d: ok (%s)\n", d.Number, check.Part, check.Got)
+		default:
+			mismatches++
+			fmt.Fprintf(w, "day %d part %d: FAIL\n", d.Number, check.Part)
+			fmt.Fprintf(w, "--- expected (%s)\n+++ actual (%s)\n", resultFile, testInput)
+			fmt.Fprintf(w, "- %s\n+ %s\n", check.Expected, check.Got)
+		}
+	}
+	return mismatches, nil
+}
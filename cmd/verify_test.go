@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyDay(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"01_test":        "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n",
+		"01_test_result": "4\n6\n",
+		// part 2 activates 12 batteries, more than a bank holds
+		"03_test":        "12345\n",
+		"03_test_result": "45\n1\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	input := filepath.Join(dir, "01_test")
+
+	for day, tc := range map[int]struct {
+		mismatches int
+		want       string
+	}{
+		1: {1, "day 1 part 1: FAIL\n--- expected (" + input + "_result)\n+++ actual (" + input + ")\n- 4\n+ 3\nday 1 part 2: ok (6)\n"},
+		3: {1, "day 3 part 1: ok (45)\nday 3 part 2: ERROR (bank 1 has 5 batteries, can't activate 12)\n"},
+		4: {0, "day 4: SKIP (no test input found for day 4"},
+	} {
+		d, _ := LookupDay(day)
+		var out strings.Builder
+		mismatches, err := verifyDay(context.Background(), d, dir, &out)
+		if err != nil || mismatches != tc.mismatches {
+			t.Errorf("day %d: got (%d, %v), want %d mismatches", day, mismatches, err, tc.mismatches)
+		}
+		if !strings.HasPrefix(out.String(), tc.want) {
+			t.Errorf("day %d: got\n%s\nwant\n%s", day, out.String(), tc.want)
+		}
+	}
+}
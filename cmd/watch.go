@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch <day>",
+	Short: "Rebuild and rerun a day whenever its source or inputs change",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().Duration("interval", 500*time.Millisecond, "how often files are checked for changes")
+	watchCmd.Flags().String("source-dir", "cmd", "directory holding the day solvers")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	d, err := dayFromArg(args[0])
+	if err != nil {
+		return err
+	}
+	inputFiles, err := selectInputFiles(cmd, d.Number)
+	if err != nil {
+		return err
+	}
+	for _, f := range inputFiles {
+		if f == stdinInput {
+			return errors.New("the standard input can't be watched")
+		}
+	}
+	interval, _ := cmd.Flags().GetDuration("interval")
+	sourceDir, _ := cmd.Flags().GetString("source-dir")
+
+	buildDir, err := os.MkdirTemp("", "adventofcode2025-watch-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+
+	w := &dayWatcher{
+		day:        d.Number,
+		inputFiles: inputFiles,
+		binary:     filepath.Join(buildDir, "adventofcode2025"),
+		flags:      forwardedFlags(cmd),
+		out:        cmd.OutOrStdout(),
+		previous:   map[string]string{},
+	}
+	watched := append([]string{filepath.Join(sourceDir, fmt.Sprintf("day%d.go", d.Number))}, inputFiles...)
+	for _, f := range inputFiles {
+		watched = append(watched, f+"_result")
+	}
+
+	log.Info().Msgf("Watching %v, press Ctrl-C to stop", watched)
+	// --timeout bounds every run of the rebuilt binary rather than the watch, which only Ctrl-C stops
+	ctx := cmd.Root().Context()
+	lastChange := time.Time{}
+	for {
+		if latest := latestModification(watched); latest.After(lastChange) {
+			lastChange = latest
+			w.run()
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// watchForwardedFlags are the flags the rebuilt binary runs with, so that it solves the day as this command would
+var watchForwardedFlags = []string{"config", "bigint", "workers", "timeout"}
+
+// forwardedFlags returns the effective values of watchForwardedFlags, whether they come from the command line,
+// the environment or the configuration
+func forwardedFlags(cmd *cobra.Command) []string {
+	args := []string{}
+	for _, name := range watchForwardedFlags {
+		if value := cmd.Flags().Lookup(name).Value.String(); value != "" {
+			args = append(args, "--"+name+"="+value)
+		}
+	}
+	return args
+}
+
+// latestModification returns the most recent modification time among the files, ignoring missing ones
+func latestModification(files []string) time.Time {
+	latest := time.Time{}
+	for _, f := range files {
+		if info, err := os.Stat(f); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// dayWatcher rebuilds the binary and reports the answers of a day, comparing them with the previous run
+type dayWatcher struct {
+	day        int
+	inputFiles []string
+	binary     string
+	flags      []string // given to every run of the binary
+	out        io.Writer
+	// previous answers by input and part
+	previous map[string]string
+}
+
+func (w *dayWatcher) run() {
+	fmt.Fprintf(w.out, "\n[%s] day %d\n", time.Now().Format(time.TimeOnly), w.day)
+
+	start := time.Now()
+	build := exec.Command("go", "build", "-o", w.binary, ".")
+	if output, err := build.CombinedOutput(); err != nil {
+		fmt.Fprintf(w.out, "build failed: %v\n%s", err, output)
+		return
+	}
+	fmt.Fprintf(w.out, "rebuilt in %v\n", time.Since(start).Round(time.Millisecond))
+
+	results, err := w.solve()
+	if err != nil {
+		fmt.Fprintln(w.out, err)
+		return
+	}
+	for _, r := range results {
+		w.report(r)
+	}
+}
+
+// solve runs the rebuilt binary on every input for both parts, parsing its JSON records
+func (w *dayWatcher) solve() ([]jsonRecord, error) {
+	args := append([]string{"day" + strconv.Itoa(w.day), "--output", "json", "--part", "both"}, w.flags...)
+	for _, f := range w.inputFiles {
+		args = append(args, "--input-file", f)
+	}
+	var stdout, stderr bytes.Buffer
+	run := exec.Command(w.binary, args...)
+	run.Stdout, run.Stderr = &stdout, &stderr
+	runErr := run.Run()
+
+	results := []jsonRecord{}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		r := jsonRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	if len(results) == 0 && runErr != nil {
+		return nil, fmt.Errorf("%w\n%s", runErr, stderr.String())
+	}
+	return results, nil
+}
+
+// jsonRecord is a result as written by the json output format
+type jsonRecord struct {
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer"`
+	Input    string        `json:"input"`
+	Duration time.Duration `json:"duration_ns"`
+	Wrapped  bool          `json:"wrapped"`
+	Error    string        `json:"error"`
+}
+
+// UnmarshalJSON accepts answers both as JSON numbers and strings
+func (r *jsonRecord) UnmarshalJSON(data []byte) error {
+	type plain jsonRecord
+	raw := struct {
+		plain
+		Answer json.RawMessage `json:"answer"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = jsonRecord(raw.plain)
+	var s string
+	if err := json.Unmarshal(raw.Answer, &s); err == nil {
+		r.Answer = s
+	} else {
+		r.Answer = string(raw.Answer)
+	}
+	return nil
+}
+
+func (w *dayWatcher) report(r jsonRecord) {
+	fmt.Fprintf(w.out, "part %d %s: ", r.Part, r.Input)
+	if r.Error != "" {
+		fmt.Fprintf(w.out, "error: %s\n", r.Error)
+		return
+	}
+	fmt.Fprintf(w.out, "%s (%v)\n", r.Answer, r.Duration.Round(time.Microsecond))
+	if r.Wrapped {
+		fmt.Fprintln(w.out, "  overflowed int, solve it with --bigint for the exact answer")
+	}
+
+	key := r.Input + "#" + strconv.Itoa(r.Part)
+	if previous, found := w.previous[key]; found && previous != r.Answer {
+		fmt.Fprintf(w.out, "  --- previous run\n  +++ this run\n  - %s\n  + %s\n", previous, r.Answer)
+	}
+	w.previous[key] = r.Answer
+
+	expected, err := readExpectedAnswers(r.Input + "_result")
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(w.out, "  can't read expected results: %v\n", err)
+		}
+		return
+	}
+	switch want := expected[r.Part-1]; want {
+	case "":
+	case r.Answer:
+		fmt.Fprintln(w.out, "  matches the expected result")
+	default:
+		fmt.Fprintf(w.out, "  --- expected (%s_result)\n  +++ actual\n  - %s\n  + %s\n", r.Input, want, r.Answer)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestJSONRecordAnswer(t *testing.T) {
+	for data, want := range map[string]string{
+		`{"day":7,"part":2,"answer":1180591620717411303424}`: "1180591620717411303424",
+		`{"day":7,"part":2,"answer":"40"}`:                   "40",
+	} {
+		r := jsonRecord{}
+		if err := json.Unmarshal([]byte(data), &r); err != nil || r.Answer != want || r.Day != 7 || r.Part != 2 {
+			t.Errorf("%s: got (%+v, %v), want answer %s", data, r, err, want)
+		}
+	}
+}
+
+func TestWatchReport(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "01_test")
+	if err := os.WriteFile(input+"_result", []byte("3\n6\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	w := &dayWatcher{day: 1, out: &out, previous: map[string]string{}}
+
+	w.report(jsonRecord{Part: 1, Input: input, Answer: "3"})
+	w.report(jsonRecord{Part: 2, Input: input, Answer: "5"})
+	w.report(jsonRecord{Part: 2, Input: input, Answer: "6"})
+	w.report(jsonRecord{Part: 1, Input: input, Error: "boom"})
+	w.report(jsonRecord{Part: 2, Input: input, Answer: "-2", Wrapped: true})
+
+	for _, want := range []string{
+		"part 1 " + input + ": 3 (0s)\n  matches the expected result\n",
+		"part 2 " + input + ": 5 (0s)\n  --- expected (" + input + "_result)\n  +++ actual\n  - 6\n  + 5\n",
+		"part 2 " + input + ": 6 (0s)\n  --- previous run\n  +++ this run\n  - 5\n  + 6\n  matches the expected result\n",
+		"part 1 " + input + ": error: boom\n",
+		"part 2 " + input + ": -2 (0s)\n  overflowed int, solve it with --bigint for the exact answer\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("got\n%s\nwant it to contain\n%s", out.String(), want)
+		}
+	}
+}
+
+func TestWatchForwardsFlags(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake binary is a shell script")
+	}
+	defer resetFlags()
+	if err := watchCmd.ParseFlags([]string{"--bigint", "--workers", "3", "--timeout", "2s", "--config", "aoc.yaml"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a binary answering with the arguments it was given
+	binary := filepath.Join(t.TempDir(), "adventofcode2025")
+	script := "#!/bin/sh\necho \"{\\\"day\\\":1,\\\"part\\\":1,\\\"answer\\\":\\\"$*\\\"}\"\n"
+	if err := os.WriteFile(binary, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	w := &dayWatcher{day: 1, inputFiles: []string{"01"}, binary: binary, flags: forwardedFlags(watchCmd)}
+	results, err := w.solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "day1 --output json --part both --config=aoc.yaml --bigint=true --workers=3 --timeout=2s --input-file 01"
+	if len(results) != 1 || results[0].Answer != want {
+		t.Errorf("got %+v, want the binary run with %q", results, want)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// workerPool bounds the goroutines solving puzzles, shared by every day running in the process
+type workerPool struct {
+	size  int
+	slots chan struct{}
+}
+
+func newWorkerPool(size int) *workerPool {
+	return &workerPool{size: size, slots: make(chan struct{}, size)}
+}
+
+// workers is the pool used by parallelMap, sized with --workers
+var workers = newWorkerPool(runtime.NumCPU())
+
+// parallelMap applies f to every item on the workers pool, returning the results in the order of the items.
+// With a single worker, items are processed sequentially on the calling goroutine.
+// No more items are started once ctx is done: the returned count tells how many were processed, and the
+// results of the rest are left zero. f must not call parallelMap itself, as it would wait for a slot it holds.
+func parallelMap[T, R any](ctx context.Context, items []T, f func(T) R) ([]R, int, error) {
+	results := make([]R, len(items))
+
+	if workers.size <= 1 {
+		for i, item := range items {
+			if err := ctx.Err(); err != nil {
+				return results, i, err
+			}
+			results[i] = f(item)
+		}
+		return results, len(items), nil
+	}
+
+	var next, processed atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers.size, len(items)) {
+		wg.Go(func() {
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(items) {
+					return
+				}
+				select {
+				case workers.slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				// select picks randomly when both are ready
+				if ctx.Err() != nil {
+					<-workers.slots
+					return
+				}
+				results[i] = f(items[i])
+				<-workers.slots
+				processed.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+
+	if n := int(processed.Load()); n < len(items) {
+		return results, n, ctx.Err()
+	}
+	return results, len(items), nil
+}
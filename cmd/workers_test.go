@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParallelMap(t *testing.T) {
+	defer func(pool *workerPool) { workers = pool }(workers)
+
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	for _, size := range []int{1, 4} {
+		workers = newWorkerPool(size)
+		results, processed, err := parallelMap(context.Background(), items, func(i int) int { return i * i })
+		if err != nil || processed != len(items) {
+			t.Fatalf("%d workers: got (%d, %v), want every item processed", size, processed, err)
+		}
+		for i, r := range results {
+			if r != i*i {
+				t.Fatalf("%d workers: result %d is %d, results must keep the order of the items", size, i, r)
+			}
+		}
+	}
+}
+
+func TestParallelMapCancelled(t *testing.T) {
+	defer func(pool *workerPool) { workers = pool }(workers)
+
+	for _, size := range []int{1, 4} {
+		workers = newWorkerPool(size)
+		ctx, cancel := context.WithCancel(context.Background())
+		_, processed, err := parallelMap(ctx, make([]int, 1000), func(i int) int {
+			cancel()
+			return i
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%d workers: got %v, want the context error", size, err)
+		}
+		if processed > size {
+			t.Errorf("%d workers: %d items were processed after the cancellation", size, processed)
+		}
+	}
+}
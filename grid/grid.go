@@ -0,0 +1,181 @@
+// Package grid holds rectangular grids of cells, as found in the puzzle inputs drawn with characters
+package grid
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Point is a position in a grid, rows growing downwards and columns to the right
+type Point struct {
+	Row int
+	Col int
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d, %d)", p.Row, p.Col)
+}
+
+// Add returns p moved by the offset d
+func (p Point) Add(d Point) Point {
+	return Point{Row: p.Row + d.Row, Col: p.Col + d.Col}
+}
+
+// Offsets to the neighbours of a point, clockwise starting from the one above
+var (
+	Orthogonal = []Point{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+	Around     = []Point{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
+)
+
+// Grid is a rectangular grid of cells holding values of type T
+type Grid[T any] interface {
+	Rows() int
+	Cols() int
+	// Get returns the value of the cell at p and whether it's set.
+	// Every cell within bounds is set in a dense grid, only the stored ones in a sparse grid.
+	Get(p Point) (T, bool)
+	// Set stores the value of the cell at p, it panics if p is out of bounds
+	Set(p Point, v T)
+	// All yields the set cells, by row and column for dense grids and in no particular order for sparse ones
+	All() iter.Seq2[Point, T]
+}
+
+// bounds is embedded by the backends
+type bounds struct {
+	rows, cols int
+}
+
+func (b bounds) Rows() int {
+	return b.rows
+}
+
+func (b bounds) Cols() int {
+	return b.cols
+}
+
+// InBounds tells whether p is a cell of the grid
+func (b bounds) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < b.rows && p.Col >= 0 && p.Col < b.cols
+}
+
+func (b bounds) mustBeInBounds(p Point) {
+	if !b.InBounds(p) {
+		panic(fmt.Sprintf("grid: %v out of bounds of a %dx%d grid", p, b.rows, b.cols))
+	}
+}
+
+// Dense stores every cell, it suits grids where most cells are meaningful
+type Dense[T any] struct {
+	bounds
+	cells []T
+}
+
+func NewDense[T any](rows, cols int) *Dense[T] {
+	return &Dense[T]{bounds: bounds{rows: rows, cols: cols}, cells: make([]T, rows*cols)}
+}
+
+func (g *Dense[T]) Get(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.cols+p.Col], true
+}
+
+func (g *Dense[T]) Set(p Point, v T) {
+	g.mustBeInBounds(p)
+	g.cells[p.Row*g.cols+p.Col] = v
+}
+
+func (g *Dense[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{Row: i / g.cols, Col: i % g.cols}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Sparse only stores the cells that are set, it suits large grids with few meaningful cells
+type Sparse[T any] struct {
+	bounds
+	cells map[Point]T
+}
+
+func NewSparse[T any](rows, cols int) *Sparse[T] {
+	return &Sparse[T]{bounds: bounds{rows: rows, cols: cols}, cells: map[Point]T{}}
+}
+
+func (g *Sparse[T]) Get(p Point) (T, bool) {
+	v, found := g.cells[p]
+	return v, found
+}
+
+func (g *Sparse[T]) Set(p Point, v T) {
+	g.mustBeInBounds(p)
+	g.cells[p] = v
+}
+
+// Delete unsets the cell at p
+func (g *Sparse[T]) Delete(p Point) {
+	delete(g.cells, p)
+}
+
+// Len returns the number of set cells
+func (g *Sparse[T]) Len() int {
+	return len(g.cells)
+}
+
+func (g *Sparse[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for p, v := range g.cells {
+			if !yield(p, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors yields the points at the given offsets from p that lie within the grid
+func Neighbors[T any](g Grid[T], p Point, offsets []Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, d := range offsets {
+			n := p.Add(d)
+			if n.Row < 0 || n.Row >= g.Rows() || n.Col < 0 || n.Col >= g.Cols() {
+				continue
+			}
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 yields the orthogonal neighbours of p within the grid
+func Neighbors4[T any](g Grid[T], p Point) iter.Seq[Point] {
+	return Neighbors(g, p, Orthogonal)
+}
+
+// Neighbors8 yields the orthogonal and diagonal neighbours of p within the grid
+func Neighbors8[T any](g Grid[T], p Point) iter.Seq[Point] {
+	return Neighbors(g, p, Around)
+}
+
+// Row returns the values of a row, unset cells holding the zero value
+func Row[T any](g Grid[T], row int) []T {
+	values := make([]T, g.Cols())
+	for col := range values {
+		values[col], _ = g.Get(Point{Row: row, Col: col})
+	}
+	return values
+}
+
+// Col returns the values of a column, unset cells holding the zero value
+func Col[T any](g Grid[T], col int) []T {
+	values := make([]T, g.Rows())
+	for row := range values {
+		values[row], _ = g.Get(Point{Row: row, Col: col})
+	}
+	return values
+}
@@ -0,0 +1,136 @@
+package grid
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func decodeRolls(_ Point, r rune) (bool, bool, error) {
+	switch r {
+	case '.':
+		return false, false, nil
+	case '@':
+		return true, true, nil
+	}
+	return false, false, errors.New("unknown element")
+}
+
+func TestParse(t *testing.T) {
+	const input = "..@\n@.@\n"
+	dense, err := ParseDense(strings.NewReader(input), decodeRolls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sparse, err := ParseSparse(strings.NewReader(input), decodeRolls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range []Grid[bool]{dense, sparse} {
+		if g.Rows() != 2 || g.Cols() != 3 {
+			t.Errorf("%T: got %dx%d, want 2x3", g, g.Rows(), g.Cols())
+		}
+		if roll, _ := g.Get(Point{Row: 1, Col: 2}); !roll {
+			t.Errorf("%T: no roll at (1, 2)", g)
+		}
+		if _, set := g.Get(Point{Row: 2, Col: 0}); set {
+			t.Errorf("%T: (2, 0) is out of bounds, it can't be set", g)
+		}
+		if got := Row(g, 1); !slices.Equal(got, []bool{true, false, true}) {
+			t.Errorf("%T: row 1 is %v", g, got)
+		}
+		if got := Col(g, 2); !slices.Equal(got, []bool{true, true}) {
+			t.Errorf("%T: column 2 is %v", g, got)
+		}
+	}
+	if _, set := dense.Get(Point{Row: 0, Col: 0}); !set {
+		t.Error("every cell within bounds is set in a dense grid")
+	}
+	if sparse.Len() != 3 {
+		t.Errorf("the sparse grid holds %d cells, want the 3 rolls", sparse.Len())
+	}
+}
+
+func TestParseLongRows(t *testing.T) {
+	// well beyond the 64 KiB lines bufio.Scanner reads by default
+	row := strings.Repeat(".@", 50_000)
+	g, err := ParseSparse(strings.NewReader(row+"\n"+row+"\n"), decodeRolls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Cols() != 100_000 || g.Len() != 100_000 {
+		t.Errorf("got %d columns holding %d rolls, want 100000 of each", g.Cols(), g.Len())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for input, want := range map[string]ParseError{
+		"..@\n@x@\n":  {Line: 2, Column: 2, Text: "x"},
+		"..@\n@.@.\n": {Line: 2, Column: 0, Text: "@.@."},
+		"..@\n@é@\n":  {Line: 2, Column: 2, Text: "é"},
+	} {
+		_, err := ParseDense(strings.NewReader(input), decodeRolls)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("%q: got %v, want a *ParseError", input, err)
+		}
+		if parseErr.Line != want.Line || parseErr.Column != want.Column || parseErr.Text != want.Text {
+			t.Errorf("%q: got %v, want it at %d:%d on %q", input, err, want.Line, want.Column, want.Text)
+		}
+	}
+
+	// cells count runes, while columns count bytes as parse.Span does
+	_, err := DenseFromRows([]string{"··@", "·x@"}, func(p Point, r rune) (bool, bool, error) {
+		if r == 'x' && p == (Point{Row: 1, Col: 1}) {
+			return false, false, errors.New("unknown element")
+		}
+		return r == '@', true, nil
+	})
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Column != 3 || parseErr.Text != "x" {
+		t.Errorf("got %v, want it at 2:3 on %q", err, "x")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := NewDense[int](3, 3)
+	for p, want := range map[Point][2]int{
+		{Row: 1, Col: 1}: {4, 8},
+		{Row: 0, Col: 0}: {2, 3},
+		{Row: 0, Col: 1}: {3, 5},
+	} {
+		got := [2]int{}
+		for range Neighbors4(g, p) {
+			got[0]++
+		}
+		for n := range Neighbors8(g, p) {
+			if !g.InBounds(n) {
+				t.Errorf("%v: neighbour %v is out of bounds", p, n)
+			}
+			got[1]++
+		}
+		if got != want {
+			t.Errorf("%v: got %v orthogonal and all around neighbours, want %v", p, got, want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := NewSparse[bool](2, 3)
+	g.Set(Point{Row: 0, Col: 2}, true)
+	g.Set(Point{Row: 1, Col: 0}, true)
+	var b strings.Builder
+	err := Render(&b, g, func(_ Point, _ bool, set bool) rune {
+		if set {
+			return '@'
+		}
+		return '.'
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "..@\n@..\n"; b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
@@ -0,0 +1,118 @@
+package grid
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Decoder turns the rune found at a position into the value of its cell.
+// Returning keep as false leaves the cell unset, which only makes a difference for sparse grids.
+type Decoder[T any] func(p Point, r rune) (value T, keep bool, err error)
+
+// ParseError reports a rune the decoder rejected, or a row whose width differs from the first one
+type ParseError struct {
+	Line   int // 1-based
+	Column int // 1-based byte of the row, 0 for the whole row
+	Text   string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %v: %q", e.Line, e.Column, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseDense reads a grid drawn with one rune per cell and one line per row
+func ParseDense[T any](r io.Reader, decode Decoder[T]) (*Dense[T], error) {
+	rows, err := readRows(r)
+	if err != nil {
+		return nil, err
+	}
+	return DenseFromRows(rows, decode)
+}
+
+// ParseSparse is like ParseDense, only storing the cells the decoder keeps
+func ParseSparse[T any](r io.Reader, decode Decoder[T]) (*Sparse[T], error) {
+	rows, err := readRows(r)
+	if err != nil {
+		return nil, err
+	}
+	return SparseFromRows(rows, decode)
+}
+
+// DenseFromRows builds a grid out of rows already split, drawn with one rune per cell
+func DenseFromRows[T any](rows []string, decode Decoder[T]) (*Dense[T], error) {
+	return fromRows(rows, NewDense[T], decode)
+}
+
+// SparseFromRows is like DenseFromRows, only storing the cells the decoder keeps
+func SparseFromRows[T any](rows []string, decode Decoder[T]) (*Sparse[T], error) {
+	return fromRows(rows, NewSparse[T], decode)
+}
+
+// readRows splits the input into lines as bufio.ScanLines does, without bounding their length
+func readRows(r io.Reader) ([]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil || len(content) == 0 {
+		return nil, err
+	}
+	rows := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	for i, row := range rows {
+		rows[i] = strings.TrimSuffix(row, "\r")
+	}
+	return rows, nil
+}
+
+func fromRows[T any, G Grid[T]](rows []string, newGrid func(rows, cols int) G, decode Decoder[T]) (G, error) {
+	var zero G
+	cols := 0
+	if len(rows) > 0 {
+		cols = utf8.RuneCountInString(rows[0])
+	}
+	for i, text := range rows {
+		if n := utf8.RuneCountInString(text); n != cols {
+			return zero, &ParseError{Line: i + 1, Text: text, Err: fmt.Errorf("row has %d cells, expected %d", n, cols)}
+		}
+	}
+
+	g := newGrid(len(rows), cols)
+	for row, text := range rows {
+		col := 0
+		for i, c := range text {
+			p := Point{Row: row, Col: col}
+			value, keep, err := decode(p, c)
+			if err != nil {
+				return zero, &ParseError{Line: row + 1, Column: i + 1, Text: string(c), Err: err}
+			}
+			if keep {
+				g.Set(p, value)
+			}
+			col++
+		}
+	}
+	return g, nil
+}
+
+// Render draws the grid with one rune per cell and one line per row.
+// encode receives every cell within bounds, set tells whether it holds a value.
+func Render[T any](w io.Writer, g Grid[T], encode func(p Point, value T, set bool) rune) error {
+	var b strings.Builder
+	for row := range g.Rows() {
+		b.Reset()
+		for col := range g.Cols() {
+			p := Point{Row: row, Col: col}
+			value, set := g.Get(p)
+			b.WriteRune(encode(p, value, set))
+		}
+		b.WriteByte('\n')
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Package intervals holds closed ranges of integers, and sets of integers stored as such ranges
+package intervals
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Interval is the closed range of integers from Lower to Upper, both included
+type Interval struct {
+	Lower int
+	Upper int
+}
+
+func (i Interval) String() string {
+	return "[" + strconv.Itoa(i.Lower) + "-" + strconv.Itoa(i.Upper) + "]"
+}
+
+// Contains tells whether x lies within the interval
+func (i Interval) Contains(x int) bool {
+	return x >= i.Lower && x <= i.Upper
+}
+
+// Len returns the number of integers in the interval. ok is false when there are more than an int holds,
+// as in the widest intervals.
+func (i Interval) Len() (n int, ok bool) {
+	// the difference of the bounds is exact as an unsigned integer
+	if d := uint64(i.Upper) - uint64(i.Lower); d < math.MaxInt {
+		return int(d) + 1, true
+	}
+	return 0, false
+}
+
+// before tells whether a ends before b starts, with a gap between them
+func before(a, b Interval) bool {
+	// a.Upper < b.Lower guarantees a.Upper+1 doesn't overflow
+	return a.Upper < b.Lower && a.Upper+1 < b.Lower
+}
+
+// ParseError reports a malformed interval, pointing at the offending text
+type ParseError struct {
+	Column int // 1-based
+	Text   string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d: %v: %q", e.Column, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads an interval written as its bounds separated by a dash, such as 11-22
+func Parse(s string) (Interval, error) {
+	lowerStr, upperStr, found := strings.Cut(s, "-")
+	if !found {
+		return Interval{}, &ParseError{Column: 1, Text: s, Err: errors.New("invalid interval format")}
+	}
+	lower, err := strconv.Atoi(lowerStr)
+	if err != nil {
+		return Interval{}, &ParseError{Column: 1, Text: lowerStr, Err: errors.New("invalid lower bound")}
+	}
+	upper, err := strconv.Atoi(upperStr)
+	if err != nil {
+		return Interval{}, &ParseError{Column: len(lowerStr) + 2, Text: upperStr, Err: errors.New("invalid upper bound")}
+	}
+	if lower > upper {
+		return Interval{}, &ParseError{Column: 1, Text: s, Err: errors.New("lower bound exceeds upper bound")}
+	}
+	return Interval{Lower: lower, Upper: upper}, nil
+}
+
+// Set is a set of integers, normalized as sorted intervals that neither overlap nor touch.
+// The zero value is an empty set.
+type Set struct {
+	intervals []Interval
+}
+
+// NewSet returns the set of the integers in any of the intervals
+func NewSet(intervals ...Interval) Set {
+	s := Set{}
+	for _, i := range intervals {
+		s.Insert(i)
+	}
+	return s
+}
+
+// Intervals returns the normalized intervals of the set, sorted
+func (s Set) Intervals() []Interval {
+	return slices.Clone(s.intervals)
+}
+
+func (s Set) String() string {
+	parts := make([]string, len(s.intervals))
+	for k, i := range s.intervals {
+		parts[k] = i.String()
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// Len returns the number of integers in the set. ok is false when there are more than an int holds.
+func (s Set) Len() (n int, ok bool) {
+	for _, i := range s.intervals {
+		length, ok := i.Len()
+		if !ok || length > math.MaxInt-n {
+			return 0, false
+		}
+		n += length
+	}
+	return n, true
+}
+
+// Find returns the interval of the set holding x, if any
+func (s Set) Find(x int) (Interval, bool) {
+	k := sort.Search(len(s.intervals), func(k int) bool { return s.intervals[k].Upper >= x })
+	if k < len(s.intervals) && s.intervals[k].Lower <= x {
+		return s.intervals[k], true
+	}
+	return Interval{}, false
+}
+
+// Contains tells whether x is in the set
+func (s Set) Contains(x int) bool {
+	_, found := s.Find(x)
+	return found
+}
+
+// Overlaps tells whether any integer of i is in the set
+func (s Set) Overlaps(i Interval) bool {
+	k := sort.Search(len(s.intervals), func(k int) bool { return s.intervals[k].Upper >= i.Lower })
+	return k < len(s.intervals) && s.intervals[k].Lower <= i.Upper
+}
+
+// Insert adds the integers of i to the set, merging it with the intervals it overlaps or touches
+func (s *Set) Insert(i Interval) {
+	from := sort.Search(len(s.intervals), func(k int) bool { return !before(s.intervals[k], i) })
+	to := sort.Search(len(s.intervals), func(k int) bool { return before(i, s.intervals[k]) })
+	if from < to {
+		i.Lower = min(i.Lower, s.intervals[from].Lower)
+		i.Upper = max(i.Upper, s.intervals[to-1].Upper)
+	}
+	s.intervals = slices.Replace(s.intervals, from, to, i)
+}
+
+// Remove takes the integers of i out of the set, trimming or splitting the intervals it overlaps
+func (s *Set) Remove(i Interval) {
+	from := sort.Search(len(s.intervals), func(k int) bool { return s.intervals[k].Upper >= i.Lower })
+	to := sort.Search(len(s.intervals), func(k int) bool { return s.intervals[k].Lower > i.Upper })
+	if from >= to {
+		return
+	}
+	left := []Interval{}
+	if first := s.intervals[from]; first.Lower < i.Lower {
+		left = append(left, Interval{Lower: first.Lower, Upper: i.Lower - 1})
+	}
+	if last := s.intervals[to-1]; last.Upper > i.Upper {
+		left = append(left, Interval{Lower: i.Upper + 1, Upper: last.Upper})
+	}
+	s.intervals = slices.Replace(s.intervals, from, to, left...)
+}
+
+// Union returns the integers in either set
+func (s Set) Union(other Set) Set {
+	result := Set{intervals: slices.Clone(s.intervals)}
+	for _, i := range other.intervals {
+		result.Insert(i)
+	}
+	return result
+}
+
+// Intersection returns the integers in both sets
+func (s Set) Intersection(other Set) Set {
+	result := Set{}
+	for a, b := 0, 0; a < len(s.intervals) && b < len(other.intervals); {
+		x, y := s.intervals[a], other.intervals[b]
+		if lower, upper := max(x.Lower, y.Lower), min(x.Upper, y.Upper); lower <= upper {
+			result.intervals = append(result.intervals, Interval{Lower: lower, Upper: upper})
+		}
+		if x.Upper < y.Upper {
+			a++
+		} else {
+			b++
+		}
+	}
+	return result
+}
+
+// Difference returns the integers in s that aren't in other
+func (s Set) Difference(other Set) Set {
+	result := Set{intervals: slices.Clone(s.intervals)}
+	for _, i := range other.intervals {
+		result.Remove(i)
+	}
+	return result
+}
+
+// Complement returns the integers within bounds that aren't in the set
+func (s Set) Complement(bounds Interval) Set {
+	return NewSet(bounds).Difference(s)
+}
@@ -0,0 +1,99 @@
+package intervals
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSetNormalized(t *testing.T) {
+	s := NewSet(Interval{10, 14}, Interval{3, 5}, Interval{16, 20}, Interval{12, 18}, Interval{6, 6})
+	want := []Interval{{3, 6}, {10, 20}}
+	if got := s.Intervals(); !slices.Equal(got, want) {
+		t.Errorf("got %v, want overlapping and touching intervals merged into %v", got, want)
+	}
+	if n, ok := s.Len(); n != 15 || !ok {
+		t.Errorf("got %d integers, want 15", n)
+	}
+}
+
+func TestSetFind(t *testing.T) {
+	s := NewSet(Interval{3, 5}, Interval{10, 14}, Interval{16, 20})
+	for x, want := range map[int]bool{2: false, 3: true, 5: true, 6: false, 15: false, 16: true, 20: true, 21: false} {
+		if i, found := s.Find(x); found != want || (found && !i.Contains(x)) {
+			t.Errorf("%d: got (%v, %v), want found to be %v", x, i, found, want)
+		}
+	}
+	for i, want := range map[Interval]bool{{0, 2}: false, {0, 3}: true, {6, 9}: false, {6, 10}: true, {11, 12}: true, {21, 30}: false} {
+		if s.Overlaps(i) != want {
+			t.Errorf("%v: got %v overlapping, want %v", i, !want, want)
+		}
+	}
+}
+
+func TestSetRemove(t *testing.T) {
+	s := NewSet(Interval{3, 5}, Interval{10, 20})
+	s.Remove(Interval{12, 13})
+	s.Remove(Interval{0, 3})
+	s.Remove(Interval{20, 30})
+	want := []Interval{{4, 5}, {10, 11}, {14, 19}}
+	if got := s.Intervals(); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := NewSet(Interval{1, 5}, Interval{10, 15})
+	b := NewSet(Interval{4, 11}, Interval{20, 20})
+	for name, tc := range map[string]struct {
+		got  Set
+		want []Interval
+	}{
+		"union":        {a.Union(b), []Interval{{1, 15}, {20, 20}}},
+		"intersection": {a.Intersection(b), []Interval{{4, 5}, {10, 11}}},
+		"difference":   {a.Difference(b), []Interval{{1, 3}, {12, 15}}},
+		"complement":   {a.Complement(Interval{0, 12}), []Interval{{0, 0}, {6, 9}}},
+	} {
+		if got := tc.got.Intervals(); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+		}
+	}
+	if got := a.Intervals(); !slices.Equal(got, []Interval{{1, 5}, {10, 15}}) {
+		t.Errorf("the operations modified their operand into %v", got)
+	}
+}
+
+func TestSetExtremeBounds(t *testing.T) {
+	all := Interval{math.MinInt, math.MaxInt}
+	s := NewSet(Interval{0, 0}).Complement(all)
+	want := []Interval{{math.MinInt, -1}, {1, math.MaxInt}}
+	if got := s.Intervals(); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, ok := s.Len(); ok {
+		t.Error("all integers but 0 don't fit in an int, the length must overflow")
+	}
+	s.Insert(Interval{0, 0})
+	if got := s.Intervals(); !slices.Equal(got, []Interval{all}) {
+		t.Errorf("got %v, want %v", got, all)
+	}
+	for i, want := range map[Interval]bool{all: false, {0, math.MaxInt}: false, {1, math.MaxInt}: true, {math.MinInt, -2}: true} {
+		if n, ok := i.Len(); ok != want || (ok && n != math.MaxInt) {
+			t.Errorf("%v: got (%d, %v), want it to fit in an int: %v", i, n, ok, want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	if i, err := Parse("11-22"); err != nil || i != (Interval{11, 22}) {
+		t.Errorf("got (%v, %v), want [11-22]", i, err)
+	}
+	for input, column := range map[string]int{"11": 1, "x-22": 1, "11-x": 4, "22-11": 1} {
+		_, err := Parse(input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Column != column {
+			t.Errorf("%q: got %v, want a *ParseError at column %d", input, err, column)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Package parse reads puzzle inputs with small composable readers: the input is cut into spans of text,
+// such as lines, blocks, delimited lists or fixed-width columns, which are then read into values.
+// Every span remembers where it was found, so errors point at the input rather than at the span.
+package parse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"dmedinag/adventofcode2025/grid"
+	"dmedinag/adventofcode2025/intervals"
+)
+
+// Error reports malformed input, pointing at the offending text
+type Error struct {
+	Line   int // 1-based, 0 if unknown
+	Column int // 1-based, 0 for a whole line
+	Text   string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %v: %q", e.Line, e.Column, e.Err, e.Text)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Span is a piece of the input, along with the position of its first byte
+type Span struct {
+	Text   string
+	Line   int // 1-based
+	Column int // 1-based
+}
+
+// Fail returns an error pointing at the whole span
+func (s Span) Fail(err error) *Error {
+	return &Error{Line: s.Line, Column: s.Column, Text: s.Text, Err: err}
+}
+
+// FailAt returns an error pointing at the text found at byte i of the span
+func (s Span) FailAt(i int, text string, err error) *Error {
+	return &Error{Line: s.Line, Column: s.Column + i, Text: text, Err: err}
+}
+
+// Slice returns the bytes i to j of the span, as strings do
+func (s Span) Slice(i, j int) Span {
+	return Span{Text: s.Text[i:j], Line: s.Line, Column: s.Column + i}
+}
+
+// locate turns an error raised while reading a span into an *Error, unless it already is one
+func locate(s Span, err error) error {
+	if located := (*Error)(nil); errors.As(err, &located) {
+		return err
+	}
+	return s.Fail(err)
+}
+
+// maxLineLength bounds the lines read, well beyond the default of bufio.Scanner, which generated inputs exceed
+const maxLineLength = 1 << 30
+
+// Lines reads every line of the input
+func Lines(r io.Reader) ([]Span, error) {
+	lines := []Span{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+	for line := 1; scanner.Scan(); line++ {
+		lines = append(lines, Span{Text: scanner.Text(), Line: line, Column: 1})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// Blocks groups the lines separated by blank ones, skipping the blank lines themselves
+func Blocks(lines []Span) [][]Span {
+	blocks := [][]Span{}
+	var block []Span
+	for _, line := range lines {
+		if line.Text == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// TrimBlank drops the blank lines at the end of the input
+func TrimBlank(lines []Span) []Span {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1].Text) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Split cuts a span around every occurrence of sep, as strings.Split does
+func Split(s Span, sep string) []Span {
+	pieces := []Span{}
+	offset := 0
+	for _, piece := range strings.Split(s.Text, sep) {
+		pieces = append(pieces, s.Slice(offset, offset+len(piece)))
+		offset += len(piece) + len(sep)
+	}
+	return pieces
+}
+
+// Columns cuts a span into fields of the given widths, separated by sep bytes.
+// Spans shorter than the columns are padded with spaces, as trailing spaces are often trimmed.
+func Columns(s Span, widths []int, sep int) ([]Span, error) {
+	total := sep * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+	if len(s.Text) > total {
+		return nil, s.FailAt(total, s.Text[total:], errors.New("text beyond the last column"))
+	}
+	s.Text += strings.Repeat(" ", total-len(s.Text))
+
+	fields := make([]Span, len(widths))
+	offset := 0
+	for i, width := range widths {
+		fields[i] = s.Slice(offset, offset+width)
+		offset += width + sep
+	}
+	return fields, nil
+}
+
+// Each reads every span, stopping at the first error
+func Each[T any](spans []Span, read func(Span) (T, error)) ([]T, error) {
+	values := make([]T, len(spans))
+	for i, s := range spans {
+		value, err := read(s)
+		if err != nil {
+			return nil, locate(s, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Runes decodes every rune of a span
+func Runes[T any](s Span, decode func(rune) (T, error)) ([]T, error) {
+	values := make([]T, 0, len(s.Text))
+	for i, r := range s.Text {
+		value, err := decode(r)
+		if err != nil {
+			return nil, s.FailAt(i, string(r), err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Int reads a span holding a decimal integer
+func Int(s Span) (int, error) {
+	n, err := strconv.Atoi(s.Text)
+	if err != nil {
+		return 0, s.Fail(errors.New("invalid integer"))
+	}
+	return n, nil
+}
+
+// Digit decodes a decimal digit, for Runes
+func Digit(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, errors.New("invalid digit")
+	}
+	return int(r - '0'), nil
+}
+
+// Range reads an integer range such as 11-22, see intervals.Parse
+func Range(s Span) (intervals.Interval, error) {
+	i, err := intervals.Parse(s.Text)
+	if intervalErr := (*intervals.ParseError)(nil); errors.As(err, &intervalErr) {
+		return intervals.Interval{}, s.FailAt(intervalErr.Column-1, intervalErr.Text, intervalErr.Err)
+	}
+	return i, err
+}
+
+// Grid reads lines drawn with one rune per cell, see grid.DenseFromRows
+func Grid[T any](lines []Span, decode grid.Decoder[T]) (*grid.Dense[T], error) {
+	g, err := grid.DenseFromRows(texts(lines), decode)
+	return g, locateGridError(lines, err)
+}
+
+// SparseGrid reads lines drawn with one rune per cell, see grid.SparseFromRows
+func SparseGrid[T any](lines []Span, decode grid.Decoder[T]) (*grid.Sparse[T], error) {
+	g, err := grid.SparseFromRows(texts(lines), decode)
+	return g, locateGridError(lines, err)
+}
+
+func texts(lines []Span) []string {
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.Text
+	}
+	return texts
+}
+
+// locateGridError turns a grid.ParseError, relative to the first line of the grid, into an *Error
+func locateGridError(lines []Span, err error) error {
+	gridErr := (*grid.ParseError)(nil)
+	if !errors.As(err, &gridErr) {
+		return err
+	}
+	line := lines[gridErr.Line-1]
+	if gridErr.Column == 0 {
+		return &Error{Line: line.Line, Text: gridErr.Text, Err: gridErr.Err}
+	}
+	return line.FailAt(gridErr.Column-1, gridErr.Text, gridErr.Err)
+}
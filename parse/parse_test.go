@@ -0,0 +1,113 @@
+package parse
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"dmedinag/adventofcode2025/grid"
+)
+
+func readLines(t *testing.T, input string) []Span {
+	t.Helper()
+	lines, err := Lines(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+// wantError checks that err is an *Error pointing at line:column
+func wantError(t *testing.T, err error, line, column int, text string) {
+	t.Helper()
+	var located *Error
+	if !errors.As(err, &located) {
+		t.Fatalf("got %v, want an *Error", err)
+	}
+	if located.Line != line || located.Column != column || located.Text != text {
+		t.Errorf("got %v, want it at %d:%d on %q", err, line, column, text)
+	}
+}
+
+func TestLongLines(t *testing.T) {
+	// well beyond the 64 KiB lines bufio.Scanner reads by default
+	long := strings.Repeat("11-22,", 100_000)
+	lines := readLines(t, "1\n"+long+"\n3\n")
+	if len(lines) != 3 || lines[1].Text != long {
+		t.Errorf("got %d lines, want the long one read whole", len(lines))
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	blocks := Blocks(readLines(t, "\n1-3\n5-7\n\n\n4\n\n"))
+	if len(blocks) != 2 || len(blocks[0]) != 2 || len(blocks[1]) != 1 {
+		t.Fatalf("got %v, want blocks of 2 and 1 lines", blocks)
+	}
+	if blocks[1][0].Line != 6 {
+		t.Errorf("the second block starts at line %d, want 6", blocks[1][0].Line)
+	}
+}
+
+func TestEachSplitRange(t *testing.T) {
+	lines := readLines(t, "1-3,5-7\n10-12,x-20\n")
+	ranges, err := Each(Split(lines[0], ","), Range)
+	if err != nil || len(ranges) != 2 || ranges[1].Lower != 5 {
+		t.Errorf("got (%v, %v), want [1-3] and [5-7]", ranges, err)
+	}
+	_, err = Each(Split(lines[1], ","), Range)
+	wantError(t, err, 2, 7, "x")
+}
+
+func TestEachLocatesErrors(t *testing.T) {
+	_, err := Each(readLines(t, "1\n2\nthree\n"), func(s Span) (int, error) {
+		n, err := Int(s)
+		if n == 2 {
+			return 0, errors.New("no twos")
+		}
+		return n, err
+	})
+	wantError(t, err, 2, 1, "2")
+}
+
+func TestRunes(t *testing.T) {
+	lines := readLines(t, "123\n4a6\n")
+	digits, err := Runes(lines[0], Digit)
+	if err != nil || !slices.Equal(digits, []int{1, 2, 3}) {
+		t.Errorf("got (%v, %v), want [1 2 3]", digits, err)
+	}
+	_, err = Runes(lines[1], Digit)
+	wantError(t, err, 2, 2, "a")
+}
+
+func TestColumns(t *testing.T) {
+	lines := readLines(t, "123 45\n 1  6\n12 345 6\n")
+	fields, err := Columns(lines[1], []int{3, 3}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields[0].Text != " 1 " || fields[1].Text != "6  " || fields[1].Column != 5 {
+		t.Errorf("got %v, want the line padded to the width of the columns", fields)
+	}
+	_, err = Columns(lines[2], []int{3, 3}, 1)
+	wantError(t, err, 3, 8, "6")
+}
+
+func TestGrid(t *testing.T) {
+	decode := func(_ grid.Point, r rune) (bool, bool, error) {
+		if r != '.' && r != '#' {
+			return false, false, errors.New("unknown cell")
+		}
+		return r == '#', true, nil
+	}
+	blocks := Blocks(readLines(t, "header\n\n.#\n#.\n\n.#\n#x\n"))
+	g, err := Grid(blocks[1], decode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wall, _ := g.Get(grid.Point{Row: 1, Col: 0}); !wall || g.Rows() != 2 {
+		t.Errorf("got a %dx%d grid without a wall at (1, 0)", g.Rows(), g.Cols())
+	}
+	_, err = SparseGrid(blocks[2], decode)
+	wantError(t, err, 7, 2, "x")
+}